@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"applemusic-api-simulator/internal/adapters/driven/cache"
+)
+
+const cacheUsage = `usage: api cache <command> [flags]
+
+Commands:
+  inspect   list the cached responses and their state (fresh, stale or expired)
+  export    write the cached responses to stdout as JSON lines
+  purge     remove cached responses (all of them by default)
+
+Run "api cache <command> -h" for the flags of each command.
+`
+
+// runCacheCommand executa o subcomando "cache", que inspeciona, exporta e remove
+// as respostas do cache em disco. O servidor precisa estar parado, pois o arquivo
+// só pode ser aberto por um processo de cada vez.
+func runCacheCommand(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(cacheUsage)
+	}
+
+	command, args := args[0], args[1:]
+	flags := flag.NewFlagSet("cache "+command, flag.ContinueOnError)
+	path := flags.String("path", envOr("CACHE_PATH", "cache.db"), "cache file")
+	prefix := flags.String("prefix", "", "only entries whose key starts with this prefix (e.g. SearchSongs)")
+
+	switch command {
+	case "inspect":
+		ttl := flags.Duration("ttl", cache.DefaultTTL, "TTL used to classify the entries; CACHE_TTL overrides the default")
+		staleTTL := flags.Duration("stale-ttl", cache.DefaultStaleTTL, "stale window used to classify the entries; CACHE_STALE_TTL overrides the default")
+		if err := parseCacheFlags(flags, args, ttl, staleTTL); err != nil {
+			return err
+		}
+		return withStore(*path, func(store *cache.Store) error {
+			return inspectCache(store, *prefix, *ttl, *staleTTL, stdout)
+		})
+
+	case "export":
+		if err := parseCacheFlags(flags, args, nil, nil); err != nil {
+			return err
+		}
+		return withStore(*path, func(store *cache.Store) error {
+			encoder := json.NewEncoder(stdout)
+			encoder.SetEscapeHTML(false)
+			return store.Entries(*prefix, func(entry cache.Entry) error {
+				return encoder.Encode(entry)
+			})
+		})
+
+	case "purge":
+		expired := flags.Bool("expired", false, "only remove entries past the TTL and the stale window")
+		ttl := flags.Duration("ttl", cache.DefaultTTL, "TTL used by -expired; CACHE_TTL overrides the default")
+		staleTTL := flags.Duration("stale-ttl", cache.DefaultStaleTTL, "stale window used by -expired; CACHE_STALE_TTL overrides the default")
+		if err := parseCacheFlags(flags, args, ttl, staleTTL); err != nil {
+			return err
+		}
+		return withStore(*path, func(store *cache.Store) error {
+			var match func(cache.Entry) bool
+			if *expired {
+				now := time.Now()
+				match = func(entry cache.Entry) bool {
+					return entry.State(now, *ttl, *staleTTL) == cache.EntryExpired
+				}
+			}
+			purged, err := store.Purge(*prefix, match)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "purged %d entries from %s\n", purged, *path)
+			return nil
+		})
+
+	default:
+		return fmt.Errorf("unknown cache command %q\n\n%s", command, cacheUsage)
+	}
+}
+
+// parseCacheFlags lê os flags do comando. Os prazos não informados assumem os
+// valores de CACHE_TTL e CACHE_STALE_TTL, os mesmos usados pelo servidor.
+func parseCacheFlags(flags *flag.FlagSet, args []string, ttl, staleTTL *time.Duration) error {
+	if ttl != nil {
+		if err := envDuration("CACHE_TTL", ttl); err != nil {
+			return err
+		}
+	}
+	if staleTTL != nil {
+		if err := envDuration("CACHE_STALE_TTL", staleTTL); err != nil {
+			return err
+		}
+	}
+	return flags.Parse(args)
+}
+
+func inspectCache(store *cache.Store, prefix string, ttl, staleTTL time.Duration, stdout io.Writer) error {
+	now := time.Now()
+	counts := make(map[cache.EntryState]int)
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tSTORED AT\tAGE\tSTATE\tSIZE")
+	err := store.Entries(prefix, func(entry cache.Entry) error {
+		state := entry.State(now, ttl, staleTTL)
+		counts[state]++
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", entry.Key, entry.StoredAt.Format(time.RFC3339),
+			now.Sub(entry.StoredAt).Round(time.Second), state, len(entry.Value))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	total := counts[cache.EntryFresh] + counts[cache.EntryStale] + counts[cache.EntryExpired]
+	fmt.Fprintf(stdout, "\n%d entries: %d fresh, %d stale, %d expired\n",
+		total, counts[cache.EntryFresh], counts[cache.EntryStale], counts[cache.EntryExpired])
+	return nil
+}
+
+func withStore(path string, fn func(*cache.Store) error) error {
+	// Não criar um arquivo vazio ao inspecionar um caminho errado
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("error opening cache file: %w", err)
+	}
+	store, err := cache.OpenStore(path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return fn(store)
+}
+
+// envDuration substitui value pela duração da variável de ambiente, se definida
+func envDuration(name string, value *time.Duration) error {
+	s := os.Getenv(name)
+	if s == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	*value = d
+	return nil
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
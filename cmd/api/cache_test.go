@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/adapters/driven/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// writeCacheFile cria um arquivo de cache temporário com as respostas informadas
+func writeCacheFile(t *testing.T, entries ...cache.Entry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cache.db")
+	store, err := cache.OpenStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// O Store não grava respostas com horários arbitrários, então elas são
+	// gravadas diretamente no bucket, no mesmo formato usado por ele
+	db, err := bolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		for _, entry := range entries {
+			record, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := tx.Bucket([]byte("responses")).Put([]byte(entry.Key), record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	return path
+}
+
+// testCacheFile cria um arquivo com uma resposta em cada estado, considerando
+// um TTL e uma janela stale de uma hora
+func testCacheFile(t *testing.T) string {
+	t.Helper()
+	now := time.Now()
+	return writeCacheFile(t,
+		cache.Entry{Key: `GetSong "" "fresh"`, StoredAt: now.Add(-10 * time.Minute), Value: json.RawMessage(`{"id":"fresh"}`)},
+		cache.Entry{Key: `SearchSongs "" "stale" 5 0`, StoredAt: now.Add(-90 * time.Minute), Value: json.RawMessage(`[]`)},
+		cache.Entry{Key: `SearchSongs "" "expired" 5 0`, StoredAt: now.Add(-3 * time.Hour), Value: json.RawMessage(`[]`)},
+	)
+}
+
+// runCache executa o subcomando e retorna a sua saída
+func runCache(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	err := runCacheCommand(args, &stdout)
+	return stdout.String(), err
+}
+
+// cacheKeys retorna as chaves guardadas no arquivo de cache
+func cacheKeys(t *testing.T, path string) []string {
+	t.Helper()
+	store, err := cache.OpenStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	var keys []string
+	require.NoError(t, store.Entries("", func(entry cache.Entry) error {
+		keys = append(keys, entry.Key)
+		return nil
+	}))
+	return keys
+}
+
+func TestCacheCommand_Inspect(t *testing.T) {
+	t.Setenv("CACHE_TTL", "")
+	t.Setenv("CACHE_STALE_TTL", "")
+	path := testCacheFile(t)
+
+	out, err := runCache(t, "inspect", "-path", path, "-ttl", "1h", "-stale-ttl", "1h")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 6)
+	assert.True(t, strings.HasPrefix(lines[0], "KEY"), lines[0])
+	assert.Contains(t, lines[1], `GetSong "" "fresh"`)
+	assert.Contains(t, lines[1], "fresh")
+	assert.Contains(t, lines[2], "expired")
+	assert.Contains(t, lines[3], "stale")
+	assert.Equal(t, "3 entries: 1 fresh, 1 stale, 1 expired", lines[5])
+
+	// Os prazos padrão vêm de CACHE_TTL e CACHE_STALE_TTL
+	t.Setenv("CACHE_TTL", "2h")
+	out, err = runCache(t, "inspect", "-path", path, "-prefix", "SearchSongs")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "GetSong")
+	assert.Contains(t, out, "2 entries: 1 fresh, 1 stale, 0 expired")
+}
+
+func TestCacheCommand_Export(t *testing.T) {
+	path := testCacheFile(t)
+
+	out, err := runCache(t, "export", "-path", path, "-prefix", "GetSong")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 1)
+
+	var entry cache.Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, `GetSong "" "fresh"`, entry.Key)
+	assert.JSONEq(t, `{"id":"fresh"}`, string(entry.Value))
+
+	out, err = runCache(t, "export", "-path", path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 3)
+}
+
+func TestCacheCommand_Purge(t *testing.T) {
+	t.Setenv("CACHE_TTL", "")
+	t.Setenv("CACHE_STALE_TTL", "")
+	path := testCacheFile(t)
+
+	// Com -expired apenas as respostas que não são mais servidas são removidas
+	out, err := runCache(t, "purge", "-path", path, "-expired", "-ttl", "1h", "-stale-ttl", "1h")
+	require.NoError(t, err)
+	assert.Equal(t, "purged 1 entries from "+path+"\n", out)
+	assert.Equal(t, []string{`GetSong "" "fresh"`, `SearchSongs "" "stale" 5 0`}, cacheKeys(t, path))
+
+	out, err = runCache(t, "purge", "-path", path, "-prefix", "SearchSongs")
+	require.NoError(t, err)
+	assert.Equal(t, "purged 1 entries from "+path+"\n", out)
+	assert.Equal(t, []string{`GetSong "" "fresh"`}, cacheKeys(t, path))
+
+	out, err = runCache(t, "purge", "-path", path)
+	require.NoError(t, err)
+	assert.Equal(t, "purged 1 entries from "+path+"\n", out)
+	assert.Empty(t, cacheKeys(t, path))
+}
+
+func TestCacheCommand_Errors(t *testing.T) {
+	path := testCacheFile(t)
+	missing := filepath.Join(t.TempDir(), "missing.db")
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"No command", nil},
+		{"Unknown command", []string{"compact", "-path", path}},
+		{"Unknown flag", []string{"export", "-path", path, "-ttl", "1h"}},
+		{"Invalid duration", []string{"inspect", "-path", path, "-ttl", "soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runCache(t, tt.args...)
+			assert.Error(t, err)
+		})
+	}
+
+	// Um caminho errado não cria um arquivo vazio
+	_, err := runCache(t, "inspect", "-path", missing)
+	assert.Error(t, err)
+	_, statErr := os.Stat(missing)
+	assert.True(t, errors.Is(statErr, os.ErrNotExist))
+
+	_, err = runCache(t, "purge", "-h")
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
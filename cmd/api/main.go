@@ -1,32 +1,370 @@
 package main
 
 import (
+	"applemusic-api-simulator/internal/adapters/driven/boltdb"
+	"applemusic-api-simulator/internal/adapters/driven/cache"
+	"applemusic-api-simulator/internal/adapters/driven/fixture"
 	"applemusic-api-simulator/internal/adapters/driven/lastfm"
+	"applemusic-api-simulator/internal/adapters/driven/memory"
 	httpadapter "applemusic-api-simulator/internal/adapters/driver/http"
+	"applemusic-api-simulator/internal/core/ports/driven"
 	"applemusic-api-simulator/internal/core/services"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 )
 
+// defaultAdminAddr é o endereço padrão das rotas de administração, na interface de loopback
+const defaultAdminAddr = "127.0.0.1:8081"
+
+// shutdownTimeout é quanto tempo aguardar as requisições em andamento ao encerrar
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	// Inicializar o adaptador Last.fm
-	lastfmAdapter, err := lastfm.NewLastFMAdapter()
+	// Subcomando de manutenção do cache em disco
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		if err := runCacheCommand(os.Args[2:], os.Stdout); err != nil {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			os.Exit(2)
+		}
+		return
+	}
+
+	// Inicializar o provedor de música
+	musicProvider, err := newMusicProvider(os.Getenv("MUSIC_PROVIDER"))
+	if err != nil {
+		log.Fatalf("Error creating music provider: %v", err)
+	}
+	baseProvider := musicProvider
+
+	// Cache na frente do provedor: em disco se CACHE_PATH estiver definido,
+	// em memória se apenas CACHE_TTL estiver definido
+	musicProvider, err = newCachedProvider(musicProvider)
 	if err != nil {
-		log.Fatalf("Error creating Last.fm adapter: %v", err)
+		log.Fatalf("Error configuring cache: %v", err)
+	}
+
+	// Prazo da busca de cada tipo de resultado
+	var serviceOptions []services.Option
+	if value := os.Getenv("SEARCH_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Error reading SEARCH_TIMEOUT: %v", err)
+		}
+		serviceOptions = append(serviceOptions, services.WithSearchTimeout(timeout))
+	}
+
+	// Sem as consultas de gêneros do Last.fm os resultados das buscas não têm
+	// gêneros, então o filtro por gênero é rejeitado
+	if lastFM, ok := baseProvider.(*lastfm.LastFMAdapter); ok && !lastFM.GenreLookups() {
+		serviceOptions = append(serviceOptions, services.WithGenreFilter(false))
+	}
+
+	// Códigos ISRC e UPC compartilhados pelos serviços, para que os filtros
+	// encontrem os códigos emitidos por qualquer rota, limitados a CODES_MAX_ENTRIES
+	maxCodes := services.DefaultMaxCodes
+	if value := os.Getenv("CODES_MAX_ENTRIES"); value != "" {
+		var err error
+		if maxCodes, err = strconv.Atoi(value); err != nil {
+			log.Fatalf("Error reading CODES_MAX_ENTRIES: %v", err)
+		}
 	}
+	codedProvider := services.NewCodedProvider(musicProvider, maxCodes)
 
 	// Inicializar o serviço de música
-	musicService := services.NewMusicService(lastfmAdapter)
+	musicService := services.NewMusicService(codedProvider, serviceOptions...)
+
+	// Modo de validação dos parâmetros: "strict" (padrão) ou "lenient"
+	validationMode, err := httpadapter.ParseValidationMode(os.Getenv("VALIDATION_MODE"))
+	if err != nil {
+		log.Fatalf("Error reading VALIDATION_MODE: %v", err)
+	}
+
+	handlerOptions := []httpadapter.Option{httpadapter.WithValidationMode(validationMode)}
+
+	// Prazo de cada requisição, incluindo as chamadas ao provedor de música
+	if value := os.Getenv("REQUEST_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Error reading REQUEST_TIMEOUT: %v", err)
+		}
+		handlerOptions = append(handlerOptions, httpadapter.WithRequestTimeout(timeout))
+	}
 
 	// Inicializar o handler de busca
-	searchHandler := httpadapter.NewSearchHandler(musicService)
+	searchHandler := httpadapter.NewSearchHandler(musicService, handlerOptions...)
+
+	// Inicializar o handler de catálogo
+	catalogHandler := httpadapter.NewCatalogHandler(musicService, handlerOptions...)
+
+	// Inicializar o handler de rankings
+	chartHandler := httpadapter.NewChartHandler(services.NewChartService(codedProvider), handlerOptions...)
+
+	// Inicializar o handler de gêneros
+	genreHandler := httpadapter.NewGenreHandler(services.NewGenreService(), handlerOptions...)
+
+	// Inicializar o handler de storefronts
+	storefrontService := services.NewStorefrontService()
+	storefrontHandler := httpadapter.NewStorefrontHandler(storefrontService)
+
+	// Inicializar os handlers da biblioteca e do histórico, guardados em memória
+	userService := services.NewUserService()
+	playlists := memory.NewPlaylistRepository()
+	libraryService := services.NewLibraryService(codedProvider, playlists, memory.NewLibraryRepository())
+	libraryHandler := httpadapter.NewLibraryHandler(libraryService, handlerOptions...)
+	historyService := services.NewHistoryService(codedProvider, memory.NewPlayHistoryRepository(0), playlists, userService)
+	historyHandler := httpadapter.NewHistoryHandler(historyService, handlerOptions...)
+
+	// Inicializar o handler das avaliações, guardadas em RATINGS_PATH ou em memória
+	ratings, err := newRatingRepository()
+	if err != nil {
+		log.Fatalf("Error opening ratings: %v", err)
+	}
+	ratingService := services.NewRatingService(codedProvider, playlists, ratings)
+	ratingHandler := httpadapter.NewRatingHandler(ratingService, handlerOptions...)
+
+	// Inicializar os handlers das rotas pessoais e de administração
+	meHandler := httpadapter.NewMeHandler(userService, storefrontService)
+	adminHandler := httpadapter.NewAdminHandler(userService, historyService)
 
 	// Configurar as rotas
-	router := httpadapter.Router(searchHandler)
+	var routerOptions []httpadapter.RouterOption
+	limiter, err := newRateLimiter()
+	if err != nil {
+		log.Fatalf("Error configuring rate limits: %v", err)
+	}
+	if limiter != nil {
+		routerOptions = append(routerOptions, httpadapter.WithRateLimiter(limiter))
+	}
+
+	// Autenticação por developer token, habilitada quando há chaves configuradas
+	if path := os.Getenv("DEVELOPER_KEYS"); path != "" {
+		keys, err := httpadapter.LoadDeveloperKeys(path)
+		if err != nil {
+			log.Fatalf("Error loading developer keys: %v", err)
+		}
+		log.Printf("Requiring developer tokens signed by %d key(s) from %s", len(keys), path)
+		routerOptions = append(routerOptions, httpadapter.WithDeveloperTokenAuth(httpadapter.NewDeveloperTokenAuth(keys)))
+	}
+	router := httpadapter.Router(httpadapter.Handlers{
+		Search:     searchHandler,
+		Catalog:    catalogHandler,
+		Chart:      chartHandler,
+		Genre:      genreHandler,
+		Storefront: storefrontHandler,
+		Me:         meHandler,
+		Library:    libraryHandler,
+		History:    historyHandler,
+		Rating:     ratingHandler,
+	}, routerOptions...)
+
+	// Iniciar o servidor de administração em um endereço separado, por padrão
+	// acessível apenas localmente, já que as suas rotas não exigem autenticação
+	adminAddr := os.Getenv("ADMIN_ADDR")
+	if adminAddr == "" {
+		adminAddr = defaultAdminAddr
+	}
+	adminServer := &http.Server{Addr: adminAddr, Handler: httpadapter.AdminRouter(adminHandler)}
+	go func() {
+		log.Printf("Starting admin server on %s", adminAddr)
+		if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error starting admin server: %v", err)
+		}
+	}()
 
 	// Iniciar o servidor
-	log.Println("Starting server on :8080")
-	if err := http.ListenAndServe(":8080", router); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		log.Println("Starting server on :8080")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error starting server: %v", err)
+		}
+	}()
+
+	// Ao receber SIGINT ou SIGTERM, aguardar as requisições em andamento e
+	// fechar os arquivos abertos, como os do cache em disco e das avaliações
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	log.Println("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, s := range []*http.Server{server, adminServer} {
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Error shutting down server on %s: %v", s.Addr, err)
+		}
+	}
+	closeAll(musicProvider, ratings)
+}
+
+// closeAll fecha os recursos que implementam io.Closer, registrando os erros
+func closeAll(resources ...any) {
+	for _, resource := range resources {
+		if closer, ok := resource.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("Error closing %T: %v", resource, err)
+			}
+		}
+	}
+}
+
+// newMusicProvider cria o provedor de música escolhido: "lastfm" (padrão) usa a
+// API do Last.fm e "fixture" serve o catálogo de FIXTURES_PATH sem acesso à rede.
+func newMusicProvider(name string) (driven.MusicProvider, error) {
+	switch name {
+	case "", "lastfm":
+		return newLastFMAdapter()
+	case "fixture":
+		path := os.Getenv("FIXTURES_PATH")
+		if path == "" {
+			path = "fixtures"
+		}
+		log.Printf("Serving catalog from fixtures in %s", path)
+		return fixture.NewFixtureAdapter(path)
+	default:
+		return nil, fmt.Errorf("unknown MUSIC_PROVIDER %q (expected lastfm or fixture)", name)
+	}
+}
+
+// newRatingRepository cria o repositório de avaliações: com RATINGS_PATH as
+// avaliações são guardadas nesse arquivo e sobrevivem a reinicializações
+func newRatingRepository() (driven.RatingRepository, error) {
+	path := os.Getenv("RATINGS_PATH")
+	if path == "" {
+		return memory.NewRatingRepository(), nil
+	}
+	repository, err := boltdb.OpenRatingRepository(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Storing ratings in %s", path)
+	return repository, nil
+}
+
+// newRateLimiter cria o limitador de requisições a partir de RATE_LIMIT, a cota
+// padrão de todas as rotas, e de RATE_LIMIT_<GRUPO> (ex.: RATE_LIMIT_SEARCH), a cota
+// de um grupo de rotas. Sem nenhuma cota definida as requisições não são limitadas.
+func newRateLimiter() (*httpadapter.RateLimiter, error) {
+	var opts []httpadapter.RateLimitOption
+	if value := os.Getenv("RATE_LIMIT"); value != "" {
+		quota, err := httpadapter.ParseQuota(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT: %w", err)
+		}
+		opts = append(opts, httpadapter.WithDefaultQuota(quota))
+	}
+	for _, route := range httpadapter.Routes {
+		name := "RATE_LIMIT_" + strings.ToUpper(route)
+		if value := os.Getenv(name); value != "" {
+			quota, err := httpadapter.ParseQuota(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			opts = append(opts, httpadapter.WithRouteQuota(route, quota))
+		}
+	}
+
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	return httpadapter.NewRateLimiter(opts...), nil
+}
+
+// newCachedProvider coloca um cache na frente do provedor. Com CACHE_PATH as respostas
+// são guardadas nesse arquivo e sobrevivem a reinicializações, sendo servidas por até
+// CACHE_STALE_TTL após expirar enquanto são atualizadas. Sem ele, CACHE_TTL habilita
+// um cache em memória limitado a CACHE_MAX_ENTRIES respostas.
+func newCachedProvider(provider driven.MusicProvider) (driven.MusicProvider, error) {
+	path := os.Getenv("CACHE_PATH")
+	if path == "" && os.Getenv("CACHE_TTL") == "" {
+		return provider, nil
+	}
+
+	ttl, staleTTL := cache.DefaultTTL, cache.DefaultStaleTTL
+	if err := envDuration("CACHE_TTL", &ttl); err != nil {
+		return nil, err
+	}
+	if err := envDuration("CACHE_STALE_TTL", &staleTTL); err != nil {
+		return nil, err
+	}
+	opts := []cache.Option{cache.WithTTL(ttl), cache.WithStaleTTL(staleTTL)}
+
+	if value := os.Getenv("CACHE_MAX_ENTRIES"); value != "" {
+		maxEntries, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CACHE_MAX_ENTRIES: %w", err)
+		}
+		opts = append(opts, cache.WithMaxEntries(maxEntries))
+	}
+
+	if path != "" {
+		log.Printf("Caching provider responses in %s for %s (stale for %s)", path, ttl, staleTTL)
+		return cache.NewDiskProvider(provider, path, opts...)
+	}
+	log.Printf("Caching provider responses for %s", ttl)
+	return cache.NewCachedProvider(provider, opts...), nil
+}
+
+// newLastFMAdapter configura o adaptador do Last.fm a partir das variáveis de ambiente.
+// Se LASTFM_CASSETTE apontar para um diretório, as requisições passam por um cassete
+// no modo de LASTFM_CASSETTE_MODE ("replay" por padrão, ou "record"); ao reproduzir
+// um cassete as credenciais não são necessárias.
+func newLastFMAdapter() (*lastfm.LastFMAdapter, error) {
+	apiKey := os.Getenv("LASTFM_API_KEY")
+	apiSecret := os.Getenv("LASTFM_API_SECRET")
+	var opts []lastfm.Option
+
+	if baseURL := os.Getenv("LASTFM_BASE_URL"); baseURL != "" {
+		opts = append(opts, lastfm.WithBaseURL(baseURL))
+	}
+
+	if value := os.Getenv("LASTFM_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LASTFM_TIMEOUT: %w", err)
+		}
+		opts = append(opts, lastfm.WithTimeout(timeout))
+	}
+
+	if value := os.Getenv("LASTFM_GENRE_LOOKUPS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LASTFM_GENRE_LOOKUPS: %w", err)
+		}
+		opts = append(opts, lastfm.WithGenreLookups(enabled))
+	}
+
+	if dir := os.Getenv("LASTFM_CASSETTE"); dir != "" {
+		mode := lastfm.CassetteMode(os.Getenv("LASTFM_CASSETTE_MODE"))
+		if mode == "" {
+			mode = lastfm.CassetteReplay
+		}
+		cassette, err := lastfm.NewCassette(dir, mode, nil)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, lastfm.WithTransport(cassette))
+
+		if mode == lastfm.CassetteReplay && apiKey == "" && apiSecret == "" {
+			apiKey, apiSecret = "replay", "replay"
+		}
+	}
+
+	if apiKey == "" || apiSecret == "" {
+		return nil, fmt.Errorf("LASTFM_API_KEY and LASTFM_API_SECRET environment variables are required")
 	}
+	return lastfm.NewLastFMAdapter(append(opts, lastfm.WithCredentials(apiKey, apiSecret))...)
 }
@@ -5,10 +5,12 @@ go 1.24.2
 require (
 	github.com/gorilla/mux v1.8.1
 	github.com/stretchr/testify v1.10.0
+	go.etcd.io/bbolt v1.4.3
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.29.0 // indirect
 )
@@ -0,0 +1,127 @@
+// Package boltdb implementa repositórios guardados em arquivos BoltDB, para
+// que os dados dos usuários sobrevivam a reinicializações do servidor.
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ratingsBucket é o bucket com as avaliações, que guarda um bucket por usuário
+var ratingsBucket = []byte("ratings")
+
+// openTimeout é quanto tempo aguardar o arquivo ser liberado por outro processo
+const openTimeout = time.Second
+
+// RatingRepository implementa a interface RatingRepository guardando as
+// avaliações em um arquivo BoltDB. O arquivo só pode ser aberto por um
+// processo de cada vez.
+type RatingRepository struct {
+	db *bolt.DB
+}
+
+// OpenRatingRepository abre o arquivo de avaliações, criando-o se não existir
+func OpenRatingRepository(path string) (*RatingRepository, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("ratings file %s is in use by another process", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening ratings file %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(ratingsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error initializing ratings file %s: %w", path, err)
+	}
+	return &RatingRepository{db: db}, nil
+}
+
+// Close fecha o arquivo de avaliações
+func (r *RatingRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *RatingRepository) GetRating(ctx context.Context, userID, resourceType, id string) (*domain.Rating, error) {
+	var rating *domain.Rating
+	err := r.db.View(func(tx *bolt.Tx) error {
+		user := tx.Bucket(ratingsBucket).Bucket([]byte(userID))
+		if user == nil {
+			return nil
+		}
+		v := user.Get(ratingKey(resourceType, id))
+		if v == nil {
+			return nil
+		}
+		var err error
+		rating, err = decodeRating(v)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rating == nil {
+		return nil, domain.NotFound(fmt.Sprintf("rating for %s %s not found", resourceType, id))
+	}
+	return rating, nil
+}
+
+func (r *RatingRepository) PutRating(ctx context.Context, userID string, rating domain.Rating) error {
+	record, err := json.Marshal(rating)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		user, err := tx.Bucket(ratingsBucket).CreateBucketIfNotExists([]byte(userID))
+		if err != nil {
+			return err
+		}
+		return user.Put(ratingKey(rating.ResourceType, rating.ResourceID), record)
+	})
+}
+
+func (r *RatingRepository) DeleteRating(ctx context.Context, userID, resourceType, id string) error {
+	var found bool
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		user := tx.Bucket(ratingsBucket).Bucket([]byte(userID))
+		if user == nil {
+			return nil
+		}
+		key := ratingKey(resourceType, id)
+		if found = user.Get(key) != nil; !found {
+			return nil
+		}
+		return user.Delete(key)
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return domain.NotFound(fmt.Sprintf("rating for %s %s not found", resourceType, id))
+	}
+	return nil
+}
+
+// ratingKey é a chave da avaliação de um recurso no bucket do usuário
+func ratingKey(resourceType, id string) []byte {
+	return []byte(resourceType + "/" + id)
+}
+
+func decodeRating(value []byte) (*domain.Rating, error) {
+	var rating domain.Rating
+	if err := json.Unmarshal(value, &rating); err != nil {
+		return nil, fmt.Errorf("error decoding rating: %w", err)
+	}
+	return &rating, nil
+}
@@ -0,0 +1,61 @@
+package boltdb
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingRepository_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.db")
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0).UTC()
+
+	repo, err := OpenRatingRepository(path)
+	require.NoError(t, err)
+	require.NoError(t, repo.PutRating(ctx, "alice", domain.Rating{ResourceType: "songs", ResourceID: "s1", Value: domain.RatingLove, UpdatedAt: now}))
+	require.NoError(t, repo.PutRating(ctx, "alice", domain.Rating{ResourceType: "albums", ResourceID: "a1", Value: domain.RatingDislike, UpdatedAt: now.Add(time.Minute)}))
+	require.NoError(t, repo.PutRating(ctx, "bob", domain.Rating{ResourceType: "songs", ResourceID: "s2", Value: domain.RatingLove, UpdatedAt: now}))
+	require.NoError(t, repo.Close())
+
+	repo, err = OpenRatingRepository(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+
+	rating, err := repo.GetRating(ctx, "alice", "songs", "s1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.Rating{ResourceType: "songs", ResourceID: "s1", Value: domain.RatingLove, UpdatedAt: now}, *rating)
+
+	rating, err = repo.GetRating(ctx, "alice", "albums", "a1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingDislike, rating.Value)
+	rating, err = repo.GetRating(ctx, "bob", "songs", "s2")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingLove, rating.Value)
+
+	_, err = repo.GetRating(ctx, "carol", "songs", "s1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+
+	require.NoError(t, repo.DeleteRating(ctx, "alice", "songs", "s1"))
+	_, err = repo.GetRating(ctx, "alice", "songs", "s1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.True(t, errors.Is(repo.DeleteRating(ctx, "alice", "songs", "s1"), domain.ErrNotFound))
+	assert.True(t, errors.Is(repo.DeleteRating(ctx, "carol", "songs", "s1"), domain.ErrNotFound))
+}
+
+func TestOpenRatingRepository_InUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.db")
+	repo, err := OpenRatingRepository(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+
+	_, err = OpenRatingRepository(path)
+	assert.ErrorContains(t, err, "in use by another process")
+}
@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+)
+
+// CachedProvider decora um driven.MusicProvider guardando em memória as respostas
+// de cada chamada por um tempo limitado. Chamadas idênticas e simultâneas que não
+// estão no cache são agrupadas em uma única chamada ao provedor.
+//
+// As chaves consideram o método e todos os seus argumentos. O storefront chega ao
+// provedor apenas como o idioma, pois os hrefs são atribuídos depois pelo serviço.
+// Erros não são guardados.
+type CachedProvider struct {
+	provider driven.MusicProvider
+	options  options
+
+	flights *group
+
+	mu       sync.Mutex
+	entries  *lru
+	requests uint64
+	misses   uint64
+}
+
+// Stats contém os contadores de uso do cache
+type Stats struct {
+	// Hits conta as respostas servidas sem chamar o provedor, incluindo as
+	// que aguardaram uma chamada idêntica em andamento
+	Hits uint64
+	// Misses conta as chamadas feitas ao provedor
+	Misses uint64
+	// Stale conta os hits servidos com respostas expiradas enquanto elas são
+	// atualizadas em segundo plano (apenas no cache em disco)
+	Stale uint64
+	// Entries é o número de respostas guardadas no momento
+	Entries int
+}
+
+// NewCachedProvider cria um cache em memória na frente do provedor informado
+func NewCachedProvider(provider driven.MusicProvider, opts ...Option) *CachedProvider {
+	o := newOptions(opts)
+	return &CachedProvider{
+		provider: provider,
+		options:  o,
+		entries:  newLRU(o.maxEntries),
+		flights:  newGroup(),
+	}
+}
+
+// Stats retorna os contadores de uso do cache
+func (c *CachedProvider) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Hits: c.requests - c.misses, Misses: c.misses, Entries: c.entries.len()}
+}
+
+func (c *CachedProvider) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	key := searchKey("SearchSongs", language, term, limit, offset)
+	return cached(ctx, c, key, cloneSlice[domain.Song], func(ctx context.Context) ([]domain.Song, error) {
+		return c.provider.SearchSongs(ctx, language, term, limit, offset)
+	})
+}
+
+func (c *CachedProvider) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
+	key := searchKey("SearchAlbums", language, term, limit, offset)
+	return cached(ctx, c, key, cloneSlice[domain.Album], func(ctx context.Context) ([]domain.Album, error) {
+		return c.provider.SearchAlbums(ctx, language, term, limit, offset)
+	})
+}
+
+func (c *CachedProvider) SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error) {
+	key := searchKey("SearchArtists", language, term, limit, offset)
+	return cached(ctx, c, key, cloneSlice[domain.Artist], func(ctx context.Context) ([]domain.Artist, error) {
+		return c.provider.SearchArtists(ctx, language, term, limit, offset)
+	})
+}
+
+func (c *CachedProvider) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	key := lookupKey("GetSong", language, id)
+	return cached(ctx, c, key, clonePointer[domain.Song], func(ctx context.Context) (*domain.Song, error) {
+		return c.provider.GetSong(ctx, language, id)
+	})
+}
+
+func (c *CachedProvider) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	key := lookupKey("GetAlbum", language, id)
+	return cached(ctx, c, key, clonePointer[domain.Album], func(ctx context.Context) (*domain.Album, error) {
+		return c.provider.GetAlbum(ctx, language, id)
+	})
+}
+
+func (c *CachedProvider) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	key := lookupKey("GetArtist", language, id)
+	return cached(ctx, c, key, clonePointer[domain.Artist], func(ctx context.Context) (*domain.Artist, error) {
+		return c.provider.GetArtist(ctx, language, id)
+	})
+}
+
+func (c *CachedProvider) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	key := lookupKey("GetAlbumTracks", language, id)
+	return cached(ctx, c, key, cloneSlice[domain.Song], func(ctx context.Context) ([]domain.Song, error) {
+		return c.provider.GetAlbumTracks(ctx, language, id)
+	})
+}
+
+func (c *CachedProvider) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	key := searchKey("GetArtistAlbums", language, id, limit, offset)
+	return cached(ctx, c, key, cloneSlice[domain.Album], func(ctx context.Context) ([]domain.Album, error) {
+		return c.provider.GetArtistAlbums(ctx, language, id, limit, offset)
+	})
+}
+
+func (c *CachedProvider) GetAlbumWithTracks(ctx context.Context, language, id string) (*driven.AlbumWithTracks, error) {
+	key := lookupKey("GetAlbumWithTracks", language, id)
+	return cached(ctx, c, key, cloneAlbumWithTracks, func(ctx context.Context) (*driven.AlbumWithTracks, error) {
+		return c.provider.GetAlbumWithTracks(ctx, language, id)
+	})
+}
+
+func (c *CachedProvider) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	key := searchKey("GetArtistWithAlbums", language, id, limit, 0)
+	return cached(ctx, c, key, cloneArtistWithAlbums, func(ctx context.Context) (*driven.ArtistWithAlbums, error) {
+		return c.provider.GetArtistWithAlbums(ctx, language, id, limit)
+	})
+}
+
+func (c *CachedProvider) GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error) {
+	key := chartKey("GetTopSongs", language, storefront, genre, limit, offset)
+	return cached(ctx, c, key, cloneSlice[domain.Song], func(ctx context.Context) ([]domain.Song, error) {
+		return c.provider.GetTopSongs(ctx, language, storefront, genre, limit, offset)
+	})
+}
+
+func (c *CachedProvider) GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error) {
+	key := chartKey("GetTopAlbums", language, "", genre, limit, offset)
+	return cached(ctx, c, key, cloneSlice[domain.Album], func(ctx context.Context) ([]domain.Album, error) {
+		return c.provider.GetTopAlbums(ctx, language, genre, limit, offset)
+	})
+}
+
+// cached retorna uma cópia da resposta guardada na chave ou a obtém com load.
+// As cópias evitam que quem chama altere a resposta guardada, já que o serviço
+// preenche hrefs e códigos nos recursos retornados.
+func cached[T any](ctx context.Context, c *CachedProvider, key string, clone func(T) T, load func(context.Context) (T, error)) (T, error) {
+	value, err := c.do(ctx, key, func(ctx context.Context) (any, error) {
+		return load(ctx)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return clone(value.(T)), nil
+}
+
+// do retorna a resposta guardada na chave. Se ela não existir, a primeira
+// requisição chama o provedor e as requisições idênticas aguardam o resultado.
+func (c *CachedProvider) do(ctx context.Context, key string, load func(context.Context) (any, error)) (any, error) {
+	c.mu.Lock()
+	c.requests++
+	value, ok := c.entries.get(key, c.options.now())
+	c.mu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	return c.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		// Outra chamada pode ter guardado a resposta desde a consulta acima
+		c.mu.Lock()
+		if value, ok := c.entries.get(key, c.options.now()); ok {
+			c.mu.Unlock()
+			return value, nil
+		}
+		c.misses++
+		c.mu.Unlock()
+
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.entries.add(key, value, c.options.now().Add(c.options.ttl))
+		c.mu.Unlock()
+		return value, nil
+	})
+}
+
+// searchKey, lookupKey e chartKey montam as chaves das respostas a partir do método e
+// de todos os seus argumentos
+func searchKey(method, language, term string, limit, offset int) string {
+	return fmt.Sprintf("%s %q %q %d %d", method, language, term, limit, offset)
+}
+
+func lookupKey(method, language, id string) string {
+	return fmt.Sprintf("%s %q %q", method, language, id)
+}
+
+func chartKey(method, language, storefront, genre string, limit, offset int) string {
+	return fmt.Sprintf("%s %q %q %q %d %d", method, language, storefront, genre, limit, offset)
+}
+
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(values)), values...)
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
+
+func cloneAlbumWithTracks(value *driven.AlbumWithTracks) *driven.AlbumWithTracks {
+	if value == nil {
+		return nil
+	}
+	return &driven.AlbumWithTracks{Album: value.Album, Tracks: cloneSlice(value.Tracks)}
+}
+
+func cloneArtistWithAlbums(value *driven.ArtistWithAlbums) *driven.ArtistWithAlbums {
+	if value == nil {
+		return nil
+	}
+	return &driven.ArtistWithAlbums{Artist: value.Artist, Albums: cloneSlice(value.Albums)}
+}
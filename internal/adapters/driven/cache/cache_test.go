@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider conta as chamadas recebidas; se release não for nil, as buscas
+// aguardam o canal ser fechado ou o contexto ser cancelado
+type countingProvider struct {
+	driven.MusicProvider
+	calls   atomic.Int32
+	err     error
+	release chan struct{}
+}
+
+func (p *countingProvider) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	p.calls.Add(1)
+	if p.release != nil {
+		select {
+		case <-p.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []domain.Song{{ID: term, Type: "songs"}}, nil
+}
+
+func (p *countingProvider) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	p.calls.Add(1)
+	return &domain.Song{ID: id, Type: "songs"}, nil
+}
+
+func TestCachedProvider_HitsAndMisses(t *testing.T) {
+	provider := &countingProvider{}
+	cache := NewCachedProvider(provider)
+	ctx := context.Background()
+
+	_, err := cache.SearchSongs(ctx, "en-US", "queen", 5, 0)
+	require.NoError(t, err)
+	songs, err := cache.SearchSongs(ctx, "en-US", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "queen", songs[0].ID)
+	assert.Equal(t, int32(1), provider.calls.Load())
+
+	// Qualquer argumento diferente é uma nova chave
+	_, err = cache.SearchSongs(ctx, "en-US", "queen", 10, 0)
+	require.NoError(t, err)
+	_, err = cache.SearchSongs(ctx, "en-US", "queen", 5, 5)
+	require.NoError(t, err)
+	_, err = cache.SearchSongs(ctx, "pt-BR", "queen", 5, 0)
+	require.NoError(t, err)
+	_, err = cache.GetSong(ctx, "en-US", "queen")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(5), provider.calls.Load())
+	assert.Equal(t, Stats{Hits: 1, Misses: 5, Entries: 5}, cache.Stats())
+}
+
+func TestCachedProvider_ReturnsCopies(t *testing.T) {
+	cache := NewCachedProvider(&countingProvider{})
+	ctx := context.Background()
+
+	songs, err := cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+	songs[0].Href = "/v1/catalog/br/songs/queen"
+
+	song, err := cache.GetSong(ctx, "", "queen")
+	require.NoError(t, err)
+	song.Href = "/v1/catalog/br/songs/queen"
+
+	songs, err = cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Empty(t, songs[0].Href)
+	song, err = cache.GetSong(ctx, "", "queen")
+	require.NoError(t, err)
+	assert.Empty(t, song.Href)
+}
+
+func TestCachedProvider_TTL(t *testing.T) {
+	provider := &countingProvider{}
+	now := time.Now()
+	cache := NewCachedProvider(provider, WithTTL(time.Minute), withClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	_, err := cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	_, err = cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), provider.calls.Load())
+
+	now = now.Add(time.Second)
+	_, err = cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), provider.calls.Load(), "expired entries are fetched again")
+}
+
+func TestCachedProvider_LRUEviction(t *testing.T) {
+	provider := &countingProvider{}
+	cache := NewCachedProvider(provider, WithMaxEntries(2))
+	ctx := context.Background()
+
+	for _, term := range []string{"a", "b", "a", "c"} {
+		_, err := cache.SearchSongs(ctx, "", term, 5, 0)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), provider.calls.Load())
+	assert.Equal(t, 2, cache.Stats().Entries)
+
+	// "b" foi a entrada usada há mais tempo quando "c" foi adicionada
+	_, err := cache.SearchSongs(ctx, "", "a", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), provider.calls.Load())
+	_, err = cache.SearchSongs(ctx, "", "b", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), provider.calls.Load())
+}
+
+func TestCachedProvider_ErrorsAreNotCached(t *testing.T) {
+	provider := &countingProvider{err: domain.UpstreamError("Last.fm request failed", assert.AnError)}
+	cache := NewCachedProvider(provider)
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.SearchSongs(context.Background(), "", "queen", 5, 0)
+		assert.ErrorIs(t, err, assert.AnError)
+	}
+	assert.Equal(t, int32(2), provider.calls.Load())
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestCachedProvider_SingleFlight(t *testing.T) {
+	provider := &countingProvider{release: make(chan struct{})}
+	cache := NewCachedProvider(provider)
+
+	const requests = 10
+	var wg sync.WaitGroup
+	errs := make([]error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = cache.SearchSongs(context.Background(), "", "queen", 5, 0)
+		}(i)
+	}
+
+	// Aguardar todas as requisições chegarem ao cache antes de liberar o provedor
+	require.Eventually(t, func() bool {
+		stats := cache.Stats()
+		return stats.Hits+stats.Misses == requests
+	}, time.Second, time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), provider.calls.Load())
+	assert.Equal(t, Stats{Hits: requests - 1, Misses: 1, Entries: 1}, cache.Stats())
+}
+
+func TestCachedProvider_SingleFlightLeaderCanceled(t *testing.T) {
+	provider := &countingProvider{release: make(chan struct{})}
+	cache := NewCachedProvider(provider)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := cache.SearchSongs(leaderCtx, "", "queen", 5, 0)
+		leaderErr <- err
+	}()
+	require.Eventually(t, func() bool { return provider.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	followerErr := make(chan error)
+	go func() {
+		_, err := cache.SearchSongs(context.Background(), "", "queen", 5, 0)
+		followerErr <- err
+	}()
+	require.Eventually(t, func() bool { return cache.Stats().Hits == 1 }, time.Second, time.Millisecond)
+
+	// O cancelamento de quem iniciou a chamada não deve falhar as demais requisições
+	cancel()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	require.Eventually(t, func() bool { return provider.calls.Load() == 2 }, time.Second, time.Millisecond)
+	close(provider.release)
+	assert.NoError(t, <-followerErr)
+}
@@ -0,0 +1,249 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+)
+
+// refreshTimeout é o prazo da atualização em segundo plano de uma resposta expirada
+const refreshTimeout = 30 * time.Second
+
+// DiskProvider decora um driven.MusicProvider guardando as respostas em um arquivo,
+// para que sobrevivam a reinicializações. Respostas expiradas há menos do que o
+// stale TTL são servidas imediatamente e atualizadas em segundo plano.
+//
+// As chaves são as mesmas do CachedProvider. Erros não são guardados.
+type DiskProvider struct {
+	provider driven.MusicProvider
+	options  options
+	store    *Store
+
+	flights   *group
+	refreshes sync.WaitGroup
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+	requests   uint64
+	misses     uint64 // Buscas no provedor feitas por requisições, sem as atualizações
+	stale      uint64
+}
+
+// NewDiskProvider cria um cache em disco no arquivo path na frente do provedor
+// informado. O arquivo é criado se não existir.
+func NewDiskProvider(provider driven.MusicProvider, path string, opts ...Option) (*DiskProvider, error) {
+	store, err := OpenStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return &DiskProvider{
+		provider:   provider,
+		options:    newOptions(opts),
+		store:      store,
+		flights:    newGroup(),
+		refreshing: make(map[string]bool),
+	}, nil
+}
+
+// Close aguarda as atualizações em segundo plano e fecha o arquivo de cache
+func (p *DiskProvider) Close() error {
+	p.refreshes.Wait()
+	return p.store.Close()
+}
+
+// Stats retorna os contadores de uso do cache
+func (p *DiskProvider) Stats() Stats {
+	entries, _ := p.store.Len()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Stats{Hits: p.requests - p.misses, Misses: p.misses, Stale: p.stale, Entries: entries}
+}
+
+func (p *DiskProvider) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	key := searchKey("SearchSongs", language, term, limit, offset)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Song, error) {
+		return p.provider.SearchSongs(ctx, language, term, limit, offset)
+	})
+}
+
+func (p *DiskProvider) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
+	key := searchKey("SearchAlbums", language, term, limit, offset)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Album, error) {
+		return p.provider.SearchAlbums(ctx, language, term, limit, offset)
+	})
+}
+
+func (p *DiskProvider) SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error) {
+	key := searchKey("SearchArtists", language, term, limit, offset)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Artist, error) {
+		return p.provider.SearchArtists(ctx, language, term, limit, offset)
+	})
+}
+
+func (p *DiskProvider) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	key := lookupKey("GetSong", language, id)
+	return persisted(ctx, p, key, func(ctx context.Context) (*domain.Song, error) {
+		return p.provider.GetSong(ctx, language, id)
+	})
+}
+
+func (p *DiskProvider) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	key := lookupKey("GetAlbum", language, id)
+	return persisted(ctx, p, key, func(ctx context.Context) (*domain.Album, error) {
+		return p.provider.GetAlbum(ctx, language, id)
+	})
+}
+
+func (p *DiskProvider) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	key := lookupKey("GetArtist", language, id)
+	return persisted(ctx, p, key, func(ctx context.Context) (*domain.Artist, error) {
+		return p.provider.GetArtist(ctx, language, id)
+	})
+}
+
+func (p *DiskProvider) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	key := lookupKey("GetAlbumTracks", language, id)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Song, error) {
+		return p.provider.GetAlbumTracks(ctx, language, id)
+	})
+}
+
+func (p *DiskProvider) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	key := searchKey("GetArtistAlbums", language, id, limit, offset)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Album, error) {
+		return p.provider.GetArtistAlbums(ctx, language, id, limit, offset)
+	})
+}
+
+func (p *DiskProvider) GetAlbumWithTracks(ctx context.Context, language, id string) (*driven.AlbumWithTracks, error) {
+	key := lookupKey("GetAlbumWithTracks", language, id)
+	return persisted(ctx, p, key, func(ctx context.Context) (*driven.AlbumWithTracks, error) {
+		return p.provider.GetAlbumWithTracks(ctx, language, id)
+	})
+}
+
+func (p *DiskProvider) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	key := searchKey("GetArtistWithAlbums", language, id, limit, 0)
+	return persisted(ctx, p, key, func(ctx context.Context) (*driven.ArtistWithAlbums, error) {
+		return p.provider.GetArtistWithAlbums(ctx, language, id, limit)
+	})
+}
+
+func (p *DiskProvider) GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error) {
+	key := chartKey("GetTopSongs", language, storefront, genre, limit, offset)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Song, error) {
+		return p.provider.GetTopSongs(ctx, language, storefront, genre, limit, offset)
+	})
+}
+
+func (p *DiskProvider) GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error) {
+	key := chartKey("GetTopAlbums", language, "", genre, limit, offset)
+	return persisted(ctx, p, key, func(ctx context.Context) ([]domain.Album, error) {
+		return p.provider.GetTopAlbums(ctx, language, genre, limit, offset)
+	})
+}
+
+// persisted decodifica a resposta guardada na chave ou a obtém com load. Cada
+// chamada decodifica a sua própria cópia, então quem chama pode alterá-la.
+func persisted[T any](ctx context.Context, p *DiskProvider, key string, load func(context.Context) (T, error)) (T, error) {
+	var value T
+	raw, err := p.do(ctx, key, func(ctx context.Context) (any, error) {
+		return load(ctx)
+	})
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
+
+// do retorna a resposta codificada guardada na chave. Respostas expiradas dentro
+// do stale TTL são retornadas e atualizadas em segundo plano; as demais são
+// buscadas no provedor, agrupando as requisições idênticas e simultâneas.
+func (p *DiskProvider) do(ctx context.Context, key string, load func(context.Context) (any, error)) (json.RawMessage, error) {
+	p.mu.Lock()
+	p.requests++
+	p.mu.Unlock()
+
+	// Um arquivo ilegível não deve impedir a resposta; a entrada é sobrescrita
+	if entry, ok, err := p.store.get(key); err == nil && ok {
+		switch entry.State(p.options.now(), p.options.ttl, p.options.staleTTL) {
+		case EntryFresh:
+			return entry.Value, nil
+		case EntryStale:
+			p.revalidate(ctx, key, load)
+			return entry.Value, nil
+		}
+	}
+
+	value, err := p.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		// Outra chamada pode ter guardado a resposta desde a consulta acima
+		if entry, ok, err := p.store.get(key); err == nil && ok &&
+			entry.State(p.options.now(), p.options.ttl, p.options.staleTTL) == EntryFresh {
+			return entry.Value, nil
+		}
+		p.mu.Lock()
+		p.misses++
+		p.mu.Unlock()
+		return p.fetch(ctx, key, load)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(json.RawMessage), nil
+}
+
+// revalidate atualiza a resposta da chave em segundo plano, a menos que uma
+// atualização dela já esteja em andamento
+func (p *DiskProvider) revalidate(ctx context.Context, key string, load func(context.Context) (any, error)) {
+	p.mu.Lock()
+	p.stale++
+	if p.refreshing[key] {
+		p.mu.Unlock()
+		return
+	}
+	p.refreshing[key] = true
+	p.mu.Unlock()
+
+	p.refreshes.Add(1)
+	go func() {
+		defer p.refreshes.Done()
+		defer func() {
+			p.mu.Lock()
+			delete(p.refreshing, key)
+			p.mu.Unlock()
+		}()
+
+		// A atualização não deve ser interrompida pelo fim da requisição que a iniciou
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+		p.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+			return p.fetch(ctx, key, load)
+		})
+	}()
+}
+
+// fetch busca a resposta no provedor e a guarda codificada no arquivo.
+// Falhas ao gravar o arquivo não impedem a resposta.
+func (p *DiskProvider) fetch(ctx context.Context, key string, load func(context.Context) (any, error)) (any, error) {
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.store.put(Entry{Key: key, StoredAt: p.options.now(), Value: raw}); err != nil {
+		log.Printf("error writing cache entry %q: %v", key, err)
+	}
+	return json.RawMessage(raw), nil
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDiskProvider(t *testing.T, provider *countingProvider, path string, opts ...Option) *DiskProvider {
+	t.Helper()
+	cache, err := NewDiskProvider(provider, path, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { cache.Close() })
+	return cache
+}
+
+func TestDiskProvider_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	provider := &countingProvider{}
+	ctx := context.Background()
+
+	cache, err := NewDiskProvider(provider, path)
+	require.NoError(t, err)
+	_, err = cache.SearchSongs(ctx, "en-US", "queen", 5, 0)
+	require.NoError(t, err)
+	_, err = cache.GetSong(ctx, "en-US", "queen")
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	cache = newTestDiskProvider(t, provider, path)
+	songs, err := cache.SearchSongs(ctx, "en-US", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Song{{ID: "queen", Type: "songs"}}, songs)
+	song, err := cache.GetSong(ctx, "en-US", "queen")
+	require.NoError(t, err)
+	assert.Equal(t, "queen", song.ID)
+
+	assert.Equal(t, int32(2), provider.calls.Load())
+	assert.Equal(t, Stats{Hits: 2, Entries: 2}, cache.Stats())
+}
+
+func TestDiskProvider_StaleWhileRevalidate(t *testing.T) {
+	provider := &countingProvider{}
+	now := time.Now()
+	cache := newTestDiskProvider(t, provider, filepath.Join(t.TempDir(), "cache.db"),
+		WithTTL(time.Minute), WithStaleTTL(time.Hour), withClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	_, err := cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+
+	// Expirada, mas dentro da janela: servida imediatamente e atualizada em segundo plano
+	now = now.Add(2 * time.Minute)
+	canceled, cancel := context.WithCancel(ctx)
+	songs, err := cache.SearchSongs(canceled, "", "queen", 5, 0)
+	cancel()
+	require.NoError(t, err)
+	assert.Equal(t, "queen", songs[0].ID)
+	cache.refreshes.Wait()
+	assert.Equal(t, int32(2), provider.calls.Load(), "the refresh outlives the request that triggered it")
+
+	// A atualização renovou a entrada
+	_, err = cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), provider.calls.Load())
+
+	// Fora da janela, a resposta é buscada novamente antes de responder
+	now = now.Add(2 * time.Hour)
+	_, err = cache.SearchSongs(ctx, "", "queen", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), provider.calls.Load())
+
+	assert.Equal(t, Stats{Hits: 2, Misses: 2, Stale: 1, Entries: 1}, cache.Stats())
+}
+
+func TestDiskProvider_ErrorsAreNotStored(t *testing.T) {
+	provider := &countingProvider{err: domain.NotFound("song queen not found")}
+	cache := newTestDiskProvider(t, provider, filepath.Join(t.TempDir(), "cache.db"))
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.SearchSongs(context.Background(), "", "queen", 5, 0)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	}
+	assert.Equal(t, int32(2), provider.calls.Load())
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestStore_EntriesAndPurge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	now := time.Now()
+	cache, err := NewDiskProvider(&countingProvider{}, path, withClock(func() time.Time { return now }))
+	require.NoError(t, err)
+	ctx := context.Background()
+	for _, term := range []string{"a", "b"} {
+		_, err := cache.SearchSongs(ctx, "", term, 5, 0)
+		require.NoError(t, err)
+	}
+	_, err = cache.GetSong(ctx, "", "a")
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	store, err := OpenStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	var keys []string
+	require.NoError(t, store.Entries("SearchSongs", func(e Entry) error {
+		keys = append(keys, e.Key)
+		assert.Equal(t, EntryFresh, e.State(now, time.Minute, time.Hour))
+		assert.Equal(t, EntryStale, e.State(now.Add(time.Minute), time.Minute, time.Hour))
+		assert.Equal(t, EntryExpired, e.State(now.Add(2*time.Hour), time.Minute, time.Hour))
+		assert.True(t, strings.HasPrefix(string(e.Value), "["))
+		return nil
+	}))
+	assert.Equal(t, []string{`SearchSongs "" "a" 5 0`, `SearchSongs "" "b" 5 0`}, keys)
+
+	purged, err := store.Purge("", func(e Entry) bool { return strings.Contains(e.Key, `"a"`) })
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	n, err := store.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	purged, err = store.Purge("", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestOpenStore_InUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	store, err := OpenStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	_, err = OpenStore(path)
+	assert.ErrorContains(t, err, "in use")
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// group agrupa chamadas idênticas e simultâneas ao provedor em uma única chamada
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call é uma chamada ao provedor em andamento, compartilhada pelas requisições
+// idênticas que chegarem antes do seu término
+type call struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newGroup() *group {
+	return &group{calls: make(map[string]*call)}
+}
+
+// do executa fn para a chave, a menos que uma chamada com a mesma chave já esteja
+// em andamento; nesse caso aguarda e retorna o resultado dela.
+func (g *group) do(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	for {
+		g.mu.Lock()
+		if pending, ok := g.calls[key]; ok {
+			g.mu.Unlock()
+
+			select {
+			case <-pending.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			// A chamada compartilhada foi interrompida pelo contexto de quem a
+			// iniciou; se esta requisição ainda pode esperar, tentar novamente
+			if isContextError(pending.err) && ctx.Err() == nil {
+				continue
+			}
+			return pending.value, pending.err
+		}
+
+		pending := &call{done: make(chan struct{})}
+		g.calls[key] = pending
+		g.mu.Unlock()
+
+		pending.value, pending.err = fn(ctx)
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(pending.done)
+
+		return pending.value, pending.err
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
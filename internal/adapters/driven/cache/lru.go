@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"container/list"
+	"time"
+)
+
+// lru guarda as respostas com prazo de validade, descartando as usadas há mais
+// tempo quando o limite de entradas é atingido. Não é seguro para uso concorrente.
+type lru struct {
+	maxEntries int
+	order      *list.List // Mais recentes na frente
+	entries    map[string]*list.Element
+}
+
+type entry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+func newLRU(maxEntries int) *lru {
+	return &lru{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get retorna o valor da chave se ele ainda for válido em now.
+// Entradas expiradas são removidas.
+func (c *lru) get(key string, now time.Time) (any, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if !now.Before(e.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true
+}
+
+// add guarda o valor da chave até expiresAt, descartando a entrada usada há
+// mais tempo se o limite for excedido
+func (c *lru) add(key string, value any, expiresAt time.Time) {
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry)
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *lru) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*entry).key)
+}
+
+func (c *lru) len() int {
+	return c.order.Len()
+}
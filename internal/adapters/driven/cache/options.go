@@ -0,0 +1,74 @@
+package cache
+
+import "time"
+
+// Valores padrão do cache
+const (
+	// DefaultTTL é o tempo em que uma resposta permanece válida no cache
+	DefaultTTL = 5 * time.Minute
+	// DefaultMaxEntries é o número máximo de respostas mantidas no cache em memória
+	DefaultMaxEntries = 1000
+	// DefaultStaleTTL é por quanto tempo após expirar uma resposta do cache em disco
+	// ainda é servida enquanto é atualizada em segundo plano
+	DefaultStaleTTL = 7 * 24 * time.Hour
+)
+
+// Option configura o CachedProvider e o DiskProvider
+type Option func(*options)
+
+type options struct {
+	ttl        time.Duration
+	maxEntries int
+	staleTTL   time.Duration
+	now        func() time.Time
+}
+
+// WithTTL define por quanto tempo uma resposta permanece válida no cache
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		if ttl > 0 {
+			o.ttl = ttl
+		}
+	}
+}
+
+// WithMaxEntries define quantas respostas o cache mantém antes de descartar
+// as usadas há mais tempo
+func WithMaxEntries(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxEntries = n
+		}
+	}
+}
+
+// WithStaleTTL define por quanto tempo após expirar uma resposta do cache em disco
+// ainda é servida enquanto é atualizada em segundo plano. Zero desabilita esse
+// comportamento e respostas expiradas passam a ser buscadas novamente no provedor.
+func WithStaleTTL(staleTTL time.Duration) Option {
+	return func(o *options) {
+		if staleTTL >= 0 {
+			o.staleTTL = staleTTL
+		}
+	}
+}
+
+// withClock substitui o relógio do cache, permitindo testar a expiração
+func withClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		ttl:        DefaultTTL,
+		maxEntries: DefaultMaxEntries,
+		staleTTL:   DefaultStaleTTL,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
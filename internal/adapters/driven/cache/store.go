@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// responsesBucket é o bucket do BoltDB onde as respostas são guardadas
+var responsesBucket = []byte("responses")
+
+// openTimeout é quanto tempo aguardar o arquivo ser liberado por outro processo,
+// como o servidor em execução
+const openTimeout = time.Second
+
+// EntryState indica se uma resposta guardada ainda pode ser servida
+type EntryState string
+
+const (
+	// EntryFresh é uma resposta dentro do TTL
+	EntryFresh EntryState = "fresh"
+	// EntryStale é uma resposta expirada que ainda é servida enquanto é atualizada
+	EntryStale EntryState = "stale"
+	// EntryExpired é uma resposta que não é mais servida
+	EntryExpired EntryState = "expired"
+)
+
+// Entry é uma resposta do provedor guardada em disco
+type Entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"storedAt"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// State classifica a resposta em now, considerando o TTL e a janela em que uma
+// resposta expirada ainda é servida enquanto é atualizada
+func (e Entry) State(now time.Time, ttl, staleTTL time.Duration) EntryState {
+	age := now.Sub(e.StoredAt)
+	switch {
+	case age < ttl:
+		return EntryFresh
+	case age < ttl+staleTTL:
+		return EntryStale
+	default:
+		return EntryExpired
+	}
+}
+
+// Store guarda as respostas do provedor em um arquivo BoltDB.
+// O arquivo só pode ser aberto por um processo de cada vez.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore abre o arquivo de cache, criando-o se não existir
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("cache file %s is in use by another process", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening cache file %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(responsesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error initializing cache file %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close fecha o arquivo de cache
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Len retorna o número de respostas guardadas
+func (s *Store) Len() (int, error) {
+	var n int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(responsesBucket).Stats().KeyN
+		return nil
+	})
+	return n, err
+}
+
+// Entries percorre as respostas guardadas em ordem de chave. Se prefix não for
+// vazio, apenas as chaves que começam com ele são percorridas.
+func (s *Store) Entries(prefix string, fn func(Entry) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(responsesBucket).Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+			entry, err := decodeEntry(k, v)
+			if err != nil {
+				return err
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Purge remove as respostas com o prefixo informado para as quais match retorna
+// true (ou todas, se match for nil) e retorna quantas foram removidas
+func (s *Store) Purge(prefix string, match func(Entry) bool) (int, error) {
+	var purged int
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(responsesBucket).Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); {
+			entry, err := decodeEntry(k, v)
+			if err != nil {
+				return err
+			}
+			if match != nil && !match(entry) {
+				k, v = c.Next()
+				continue
+			}
+			// Após Delete, o cursor precisa ser reposicionado na chave seguinte
+			deleted := append([]byte(nil), k...)
+			if err := c.Delete(); err != nil {
+				return err
+			}
+			purged++
+			k, v = c.Seek(deleted)
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// get retorna a resposta guardada na chave, se existir
+func (s *Store) get(key string) (Entry, bool, error) {
+	var entry Entry
+	var found bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(responsesBucket).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		var err error
+		entry, err = decodeEntry([]byte(key), v)
+		found = err == nil
+		return err
+	})
+	return entry, found, err
+}
+
+// put guarda a resposta na chave, substituindo a anterior
+func (s *Store) put(entry Entry) error {
+	record, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(responsesBucket).Put([]byte(entry.Key), record)
+	})
+}
+
+func decodeEntry(key, value []byte) (Entry, error) {
+	var entry Entry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return Entry{}, fmt.Errorf("error decoding cache entry %q: %w", key, err)
+	}
+	entry.Key = string(key)
+	return entry, nil
+}
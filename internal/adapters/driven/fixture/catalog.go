@@ -0,0 +1,150 @@
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// catalogFile é o formato dos arquivos de fixture (JSON ou YAML).
+// Os campos names e localizedNotes são mapas de tag BCP 47 para o texto localizado.
+type catalogFile struct {
+	Artists []artistFixture `json:"artists" yaml:"artists"`
+	Albums  []albumFixture  `json:"albums" yaml:"albums"`
+	Songs   []songFixture   `json:"songs" yaml:"songs"`
+}
+
+type artistFixture struct {
+	ID         string            `json:"id" yaml:"id"`
+	Name       string            `json:"name" yaml:"name"`
+	Names      map[string]string `json:"names" yaml:"names"`
+	GenreNames []string          `json:"genreNames" yaml:"genreNames"`
+	ArtworkURL string            `json:"artworkUrl" yaml:"artworkUrl"`
+	URL        string            `json:"url" yaml:"url"`
+}
+
+type albumFixture struct {
+	ID             string            `json:"id" yaml:"id"`
+	Name           string            `json:"name" yaml:"name"`
+	Names          map[string]string `json:"names" yaml:"names"`
+	Artist         string            `json:"artist" yaml:"artist"`
+	GenreNames     []string          `json:"genreNames" yaml:"genreNames"`
+	ReleaseDate    string            `json:"releaseDate" yaml:"releaseDate"`
+	RecordLabel    string            `json:"recordLabel" yaml:"recordLabel"`
+	Copyright      string            `json:"copyright" yaml:"copyright"`
+	UPC            string            `json:"upc" yaml:"upc"`
+	ContentRating  string            `json:"contentRating" yaml:"contentRating"`
+	IsCompilation  bool              `json:"isCompilation" yaml:"isCompilation"`
+	Notes          string            `json:"notes" yaml:"notes"`
+	LocalizedNotes map[string]string `json:"localizedNotes" yaml:"localizedNotes"`
+	ArtworkURL     string            `json:"artworkUrl" yaml:"artworkUrl"`
+	URL            string            `json:"url" yaml:"url"`
+}
+
+type songFixture struct {
+	ID               string            `json:"id" yaml:"id"`
+	Name             string            `json:"name" yaml:"name"`
+	Names            map[string]string `json:"names" yaml:"names"`
+	Album            string            `json:"album" yaml:"album"`
+	Artist           string            `json:"artist" yaml:"artist"`
+	TrackNumber      int               `json:"trackNumber" yaml:"trackNumber"`
+	DiscNumber       int               `json:"discNumber" yaml:"discNumber"`
+	DurationInMillis int               `json:"durationInMillis" yaml:"durationInMillis"`
+	ISRC             string            `json:"isrc" yaml:"isrc"`
+	ComposerName     string            `json:"composerName" yaml:"composerName"`
+	HasLyrics        bool              `json:"hasLyrics" yaml:"hasLyrics"`
+	GenreNames       []string          `json:"genreNames" yaml:"genreNames"`
+	ReleaseDate      string            `json:"releaseDate" yaml:"releaseDate"`
+	URL              string            `json:"url" yaml:"url"`
+}
+
+// loadCatalog lê um arquivo de fixture ou todos os arquivos .json, .yaml e .yml
+// de um diretório, em ordem alfabética, combinando-os em um único catálogo.
+func loadCatalog(path string) (*catalogFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixtures: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading fixtures: %w", err)
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() && isFixtureFile(entry.Name()) {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(files)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no fixture files found in %s", path)
+		}
+	}
+
+	catalog := &catalogFile{}
+	for _, file := range files {
+		part, err := decodeFile(file)
+		if err != nil {
+			return nil, err
+		}
+		catalog.Artists = append(catalog.Artists, part.Artists...)
+		catalog.Albums = append(catalog.Albums, part.Albums...)
+		catalog.Songs = append(catalog.Songs, part.Songs...)
+	}
+	return catalog, nil
+}
+
+func isFixtureFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// decodeFile decodifica um arquivo de fixture conforme a sua extensão
+func decodeFile(file string) (*catalogFile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %w", file, err)
+	}
+
+	var part catalogFile
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		err = json.Unmarshal(data, &part)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &part)
+	default:
+		return nil, fmt.Errorf("unsupported fixture format: %s", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding fixture %s: %w", file, err)
+	}
+	return &part, nil
+}
+
+// localized retorna o texto no idioma solicitado, tentando a tag completa e
+// depois apenas o idioma principal (ex.: "pt" para "pt-BR"), e por fim o padrão.
+func localized(values map[string]string, language, fallback string) string {
+	if language == "" || len(values) == 0 {
+		return fallback
+	}
+	primary, _, _ := strings.Cut(language, "-")
+	for _, candidate := range []string{language, primary} {
+		for tag, value := range values {
+			if strings.EqualFold(tag, candidate) && value != "" {
+				return value
+			}
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,401 @@
+package fixture
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+)
+
+// FixtureAdapter implementa a interface MusicProvider servindo um catálogo
+// carregado de arquivos JSON/YAML em memória, sem nenhum acesso à rede.
+// As músicas e álbuns referenciam artistas e álbuns pelos seus IDs.
+type FixtureAdapter struct {
+	artists []*artistFixture
+	albums  []*albumFixture
+	songs   []*songFixture
+
+	artistsByID map[string]*artistFixture
+	albumsByID  map[string]*albumFixture
+	songsByID   map[string]*songFixture
+
+	// Faixas por álbum, ordenadas por disco e número da faixa
+	tracksByAlbum map[string][]*songFixture
+	// Álbuns por artista, na ordem das fixtures
+	albumsByArtist map[string][]*albumFixture
+}
+
+// NewFixtureAdapter carrega as fixtures de path, que pode ser um arquivo ou um
+// diretório, e valida as referências entre os recursos.
+func NewFixtureAdapter(path string) (*FixtureAdapter, error) {
+	catalog, err := loadCatalog(path)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &FixtureAdapter{
+		artistsByID:    make(map[string]*artistFixture),
+		albumsByID:     make(map[string]*albumFixture),
+		songsByID:      make(map[string]*songFixture),
+		tracksByAlbum:  make(map[string][]*songFixture),
+		albumsByArtist: make(map[string][]*albumFixture),
+	}
+
+	for i := range catalog.Artists {
+		artist := &catalog.Artists[i]
+		if err := checkResource("artist", artist.ID, artist.Name, a.artistsByID[artist.ID] != nil); err != nil {
+			return nil, err
+		}
+		a.artists = append(a.artists, artist)
+		a.artistsByID[artist.ID] = artist
+	}
+
+	for i := range catalog.Albums {
+		album := &catalog.Albums[i]
+		if err := checkResource("album", album.ID, album.Name, a.albumsByID[album.ID] != nil); err != nil {
+			return nil, err
+		}
+		if a.artistsByID[album.Artist] == nil {
+			return nil, fmt.Errorf("album %s references unknown artist %q", album.ID, album.Artist)
+		}
+		a.albums = append(a.albums, album)
+		a.albumsByID[album.ID] = album
+		a.albumsByArtist[album.Artist] = append(a.albumsByArtist[album.Artist], album)
+	}
+
+	for i := range catalog.Songs {
+		song := &catalog.Songs[i]
+		if err := checkResource("song", song.ID, song.Name, a.songsByID[song.ID] != nil); err != nil {
+			return nil, err
+		}
+		if song.Album != "" {
+			album := a.albumsByID[song.Album]
+			if album == nil {
+				return nil, fmt.Errorf("song %s references unknown album %q", song.ID, song.Album)
+			}
+			if song.Artist == "" {
+				song.Artist = album.Artist
+			}
+			a.tracksByAlbum[song.Album] = append(a.tracksByAlbum[song.Album], song)
+		}
+		if a.artistsByID[song.Artist] == nil {
+			return nil, fmt.Errorf("song %s references unknown artist %q", song.ID, song.Artist)
+		}
+		a.songs = append(a.songs, song)
+		a.songsByID[song.ID] = song
+	}
+
+	for _, tracks := range a.tracksByAlbum {
+		sort.SliceStable(tracks, func(i, j int) bool {
+			if tracks[i].DiscNumber != tracks[j].DiscNumber {
+				return tracks[i].DiscNumber < tracks[j].DiscNumber
+			}
+			return tracks[i].TrackNumber < tracks[j].TrackNumber
+		})
+	}
+
+	return a, nil
+}
+
+// checkResource valida os campos obrigatórios de um recurso das fixtures
+func checkResource(kind, id, name string, duplicated bool) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("%s %q has no id", kind, name)
+	case name == "":
+		return fmt.Errorf("%s %s has no name", kind, id)
+	case duplicated:
+		return fmt.Errorf("duplicated %s id %s", kind, id)
+	}
+	return nil
+}
+
+// SearchSongs busca músicas cujo nome ou artista contenham todas as palavras do termo
+func (a *FixtureAdapter) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	songs := []domain.Song{}
+	for _, song := range a.songs {
+		artist := a.artistsByID[song.Artist]
+		if matches(term, append(names(song.Name, song.Names), names(artist.Name, artist.Names)...)) {
+			songs = append(songs, a.toSong(language, song))
+		}
+	}
+	return paginate(songs, limit, offset), nil
+}
+
+// SearchAlbums busca álbuns cujo nome ou artista contenham todas as palavras do termo
+func (a *FixtureAdapter) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
+	albums := []domain.Album{}
+	for _, album := range a.albums {
+		artist := a.artistsByID[album.Artist]
+		if matches(term, append(names(album.Name, album.Names), names(artist.Name, artist.Names)...)) {
+			albums = append(albums, a.toAlbum(language, album))
+		}
+	}
+	return paginate(albums, limit, offset), nil
+}
+
+// SearchArtists busca artistas cujo nome contenha todas as palavras do termo
+func (a *FixtureAdapter) SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error) {
+	artists := []domain.Artist{}
+	for _, artist := range a.artists {
+		if matches(term, names(artist.Name, artist.Names)) {
+			artists = append(artists, a.toArtist(language, artist))
+		}
+	}
+	return paginate(artists, limit, offset), nil
+}
+
+// GetSong retorna a música com o ID informado
+func (a *FixtureAdapter) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	song, ok := a.songsByID[id]
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("song %s not found", id))
+	}
+	result := a.toSong(language, song)
+	return &result, nil
+}
+
+// GetAlbum retorna o álbum com o ID informado
+func (a *FixtureAdapter) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	album, ok := a.albumsByID[id]
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("album %s not found", id))
+	}
+	result := a.toAlbum(language, album)
+	return &result, nil
+}
+
+// GetArtist retorna o artista com o ID informado
+func (a *FixtureAdapter) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	artist, ok := a.artistsByID[id]
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("artist %s not found", id))
+	}
+	result := a.toArtist(language, artist)
+	return &result, nil
+}
+
+// GetAlbumTracks retorna as faixas do álbum ordenadas por disco e número
+func (a *FixtureAdapter) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	if _, ok := a.albumsByID[id]; !ok {
+		return nil, domain.NotFound(fmt.Sprintf("album %s not found", id))
+	}
+
+	tracks := make([]domain.Song, 0, len(a.tracksByAlbum[id]))
+	for _, song := range a.tracksByAlbum[id] {
+		tracks = append(tracks, a.toSong(language, song))
+	}
+	return tracks, nil
+}
+
+// GetArtistAlbums retorna os álbuns do artista paginados por limit e offset
+func (a *FixtureAdapter) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	if _, ok := a.artistsByID[id]; !ok {
+		return nil, domain.NotFound(fmt.Sprintf("artist %s not found", id))
+	}
+
+	albums := make([]domain.Album, 0, len(a.albumsByArtist[id]))
+	for _, album := range a.albumsByArtist[id] {
+		albums = append(albums, a.toAlbum(language, album))
+	}
+	return paginate(albums, limit, offset), nil
+}
+
+// GetAlbumWithTracks retorna o álbum com o ID informado e as suas faixas
+func (a *FixtureAdapter) GetAlbumWithTracks(ctx context.Context, language, id string) (*driven.AlbumWithTracks, error) {
+	album, err := a.GetAlbum(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := a.GetAlbumTracks(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	return &driven.AlbumWithTracks{Album: *album, Tracks: tracks}, nil
+}
+
+// GetArtistWithAlbums retorna o artista com o ID informado e os seus primeiros limit álbuns
+func (a *FixtureAdapter) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	artist, err := a.GetArtist(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	albums, err := a.GetArtistAlbums(ctx, language, id, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &driven.ArtistWithAlbums{Artist: *artist, Albums: albums}, nil
+}
+
+// GetTopSongs retorna as músicas do gênero com o ID informado e dos seus
+// subgêneros, ou todas se genre for vazio. As fixtures não têm dados de
+// popularidade, então o ranking segue a ordem das fixtures e é o mesmo em
+// todos os storefronts.
+func (a *FixtureAdapter) GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error) {
+	if err := checkGenre(genre); err != nil {
+		return nil, err
+	}
+
+	songs := []domain.Song{}
+	for _, song := range a.songs {
+		result := a.toSong(language, song)
+		if genre == "" || domain.HasGenre(result.Attributes.GenreNames, genre) {
+			songs = append(songs, result)
+		}
+	}
+	return paginate(songs, limit, offset), nil
+}
+
+// GetTopAlbums retorna os álbuns do gênero com o ID informado e dos seus
+// subgêneros, ou todos se genre for vazio, na ordem das fixtures
+func (a *FixtureAdapter) GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error) {
+	if err := checkGenre(genre); err != nil {
+		return nil, err
+	}
+
+	albums := []domain.Album{}
+	for _, album := range a.albums {
+		if genre == "" || domain.HasGenre(album.GenreNames, genre) {
+			albums = append(albums, a.toAlbum(language, album))
+		}
+	}
+	return paginate(albums, limit, offset), nil
+}
+
+func (a *FixtureAdapter) toSong(language string, song *songFixture) domain.Song {
+	artist := a.artistsByID[song.Artist]
+	attributes := domain.SongAttributes{
+		Name:             localized(song.Names, language, song.Name),
+		ArtistName:       localized(artist.Names, language, artist.Name),
+		TrackNumber:      song.TrackNumber,
+		DiscNumber:       song.DiscNumber,
+		DurationInMillis: song.DurationInMillis,
+		ISRC:             song.ISRC,
+		ComposerName:     song.ComposerName,
+		HasLyrics:        song.HasLyrics,
+		GenreNames:       song.GenreNames,
+		ReleaseDate:      song.ReleaseDate,
+		URL:              song.URL,
+		PlayParams: domain.PlayParams{
+			ID:   song.ID,
+			Kind: "song",
+		},
+	}
+
+	// Atributos não informados na música são herdados do álbum
+	if album, ok := a.albumsByID[song.Album]; ok {
+		attributes.AlbumName = localized(album.Names, language, album.Name)
+		attributes.Artwork.URL = album.ArtworkURL
+		if attributes.ReleaseDate == "" {
+			attributes.ReleaseDate = album.ReleaseDate
+		}
+		if len(attributes.GenreNames) == 0 {
+			attributes.GenreNames = album.GenreNames
+		}
+	}
+	attributes.GenreNames = append([]string{}, attributes.GenreNames...)
+
+	return domain.Song{
+		ID:         song.ID,
+		Type:       "songs",
+		Attributes: attributes,
+	}
+}
+
+func (a *FixtureAdapter) toAlbum(language string, album *albumFixture) domain.Album {
+	artist := a.artistsByID[album.Artist]
+	trackCount := len(a.tracksByAlbum[album.ID])
+
+	return domain.Album{
+		ID:   album.ID,
+		Type: "albums",
+		Attributes: domain.AlbumAttributes{
+			Name:          localized(album.Names, language, album.Name),
+			ArtistName:    localized(artist.Names, language, artist.Name),
+			GenreNames:    append([]string{}, album.GenreNames...),
+			ReleaseDate:   album.ReleaseDate,
+			RecordLabel:   album.RecordLabel,
+			Copyright:     album.Copyright,
+			UPC:           album.UPC,
+			ContentRating: album.ContentRating,
+			IsCompilation: album.IsCompilation,
+			TrackCount:    trackCount,
+			IsSingle:      trackCount == 1,
+			IsComplete:    true,
+			URL:           album.URL,
+			Artwork: domain.Artwork{
+				URL: album.ArtworkURL,
+			},
+			EditorialNotes: domain.EditorialNotes{
+				Standard: localized(album.LocalizedNotes, language, album.Notes),
+			},
+			PlayParams: domain.PlayParams{
+				ID:   album.ID,
+				Kind: "album",
+			},
+		},
+	}
+}
+
+func (a *FixtureAdapter) toArtist(language string, artist *artistFixture) domain.Artist {
+	return domain.Artist{
+		ID:   artist.ID,
+		Type: "artists",
+		Attributes: domain.ArtistAttributes{
+			Name:       localized(artist.Names, language, artist.Name),
+			GenreNames: append([]string{}, artist.GenreNames...),
+			URL:        artist.URL,
+			Artwork: domain.Artwork{
+				URL: artist.ArtworkURL,
+			},
+		},
+	}
+}
+
+// matches indica se todas as palavras do termo aparecem em algum dos nomes,
+// de modo que um recurso pode ser encontrado por qualquer uma das suas localizações
+func matches(term string, candidates []string) bool {
+	text := strings.ToLower(strings.Join(candidates, " "))
+	words := strings.Fields(strings.ToLower(term))
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkGenre retorna domain.ErrNotFound se o gênero informado não existir
+func checkGenre(id string) error {
+	if _, ok := domain.FindGenre(id); id != "" && !ok {
+		return domain.NotFound(fmt.Sprintf("genre %s not found", id))
+	}
+	return nil
+}
+
+// names retorna o nome padrão seguido dos nomes localizados
+func names(name string, localizedNames map[string]string) []string {
+	result := []string{name}
+	for _, localizedName := range localizedNames {
+		result = append(result, localizedName)
+	}
+	return result
+}
+
+// paginate aplica offset e limit a uma lista de resultados
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
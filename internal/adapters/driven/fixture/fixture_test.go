@@ -0,0 +1,155 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureAdapter_Lookups(t *testing.T) {
+	adapter, err := NewFixtureAdapter("testdata")
+	require.NoError(t, err)
+
+	song, err := adapter.GetSong(context.Background(), "", "first-song")
+	require.NoError(t, err)
+	assert.Equal(t, "First Song", song.Attributes.Name)
+	assert.Equal(t, "Test Artist", song.Attributes.ArtistName)
+	assert.Equal(t, "Test Album", song.Attributes.AlbumName)
+	assert.Equal(t, "2020-01-01", song.Attributes.ReleaseDate, "release date is inherited from the album")
+	assert.Equal(t, []string{"Rock"}, song.Attributes.GenreNames)
+	assert.Equal(t, "USAAA0000001", song.Attributes.ISRC)
+
+	album, err := adapter.GetAlbum(context.Background(), "", "test-album")
+	require.NoError(t, err)
+	assert.Equal(t, 2, album.Attributes.TrackCount)
+	assert.Equal(t, "Notes", album.Attributes.EditorialNotes.Standard)
+
+	tracks, err := adapter.GetAlbumTracks(context.Background(), "", "test-album")
+	require.NoError(t, err)
+	require.Len(t, tracks, 2)
+	assert.Equal(t, "first-song", tracks[0].ID, "tracks are ordered by track number")
+	assert.Equal(t, "second-song", tracks[1].ID)
+
+	albums, err := adapter.GetArtistAlbums(context.Background(), "", "test-artist", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, albums, 1)
+	assert.Equal(t, "test-album", albums[0].ID)
+
+	_, err = adapter.GetArtist(context.Background(), "", "missing")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	_, err = adapter.GetAlbumTracks(context.Background(), "", "missing")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestFixtureAdapter_Charts(t *testing.T) {
+	adapter, err := NewFixtureAdapter("testdata")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	songs, err := adapter.GetTopSongs(ctx, "", "us", "", 2, 1)
+	require.NoError(t, err)
+	require.Len(t, songs, 2)
+	assert.Equal(t, "first-song", songs[0].ID, "charts follow the fixtures order")
+
+	// Músicas herdam os gêneros do álbum (Rock)
+	songs, err = adapter.GetTopSongs(ctx, "", "", "21", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+
+	albums, err := adapter.GetTopAlbums(ctx, "", "21", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, albums, 1)
+	albums, err = adapter.GetTopAlbums(ctx, "", "11", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, albums)
+
+	_, err = adapter.GetTopAlbums(ctx, "", "999", 10, 0)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestFixtureAdapter_Localization(t *testing.T) {
+	adapter, err := NewFixtureAdapter("testdata")
+	require.NoError(t, err)
+
+	album, err := adapter.GetAlbum(context.Background(), "pt-BR", "test-album")
+	require.NoError(t, err)
+	assert.Equal(t, "Álbum de Teste", album.Attributes.Name)
+	assert.Equal(t, "Artista de Teste", album.Attributes.ArtistName, "primary language subtag is used as fallback")
+	assert.Equal(t, "Notas", album.Attributes.EditorialNotes.Standard)
+
+	album, err = adapter.GetAlbum(context.Background(), "pt-PT", "test-album")
+	require.NoError(t, err)
+	assert.Equal(t, "Test Album", album.Attributes.Name)
+
+	// A busca encontra recursos pelo nome em qualquer idioma
+	artists, err := adapter.SearchArtists(context.Background(), "en-US", "artista", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, artists, 1)
+	assert.Equal(t, "Test Artist", artists[0].Attributes.Name)
+}
+
+func TestFixtureAdapter_Search(t *testing.T) {
+	adapter, err := NewFixtureAdapter("testdata")
+	require.NoError(t, err)
+
+	songs, err := adapter.SearchSongs(context.Background(), "", "test artist song", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 2)
+
+	songs, err = adapter.SearchSongs(context.Background(), "", "song", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, "first-song", songs[0].ID)
+
+	songs, err = adapter.SearchSongs(context.Background(), "", "song", 5, 10)
+	require.NoError(t, err)
+	assert.Empty(t, songs)
+
+	albums, err := adapter.SearchAlbums(context.Background(), "", "nothing", 5, 0)
+	require.NoError(t, err)
+	assert.Empty(t, albums)
+}
+
+func TestNewFixtureAdapter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"Unknown artist", "albums:\n  - id: a\n    name: A\n    artist: nobody\n"},
+		{"Unknown album", "artists:\n  - id: x\n    name: X\nsongs:\n  - id: s\n    name: S\n    album: missing\n"},
+		{"Duplicated id", "artists:\n  - id: x\n    name: X\n  - id: x\n    name: Y\n"},
+		{"Missing name", "artists:\n  - id: x\n"},
+		{"Invalid YAML", "artists: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "catalog.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			_, err := NewFixtureAdapter(path)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewFixtureAdapter(t.TempDir())
+	assert.Error(t, err, "a directory without fixtures is rejected")
+}
+
+// O catálogo de exemplo distribuído com o projeto deve continuar válido
+func TestNewFixtureAdapter_SampleCatalog(t *testing.T) {
+	adapter, err := NewFixtureAdapter(filepath.Join("..", "..", "..", "..", "fixtures"))
+	require.NoError(t, err)
+
+	songs, err := adapter.SearchSongs(context.Background(), "ja", "queen bohemian", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, "ボヘミアン・ラプソディ", songs[0].Attributes.Name)
+}
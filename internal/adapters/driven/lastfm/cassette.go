@@ -0,0 +1,212 @@
+package lastfm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CassetteMode define se o cassete grava as interações com o Last.fm ou as reproduz
+type CassetteMode string
+
+const (
+	// CassetteRecord repassa as requisições ao Last.fm e grava cada resposta em disco
+	CassetteRecord CassetteMode = "record"
+	// CassetteReplay responde apenas com interações gravadas, sem acessar a rede
+	CassetteReplay CassetteMode = "replay"
+)
+
+// Valor gravado no lugar das credenciais
+const redacted = "REDACTED"
+
+// Parâmetros da query que contêm credenciais e são removidos dos cassetes
+var secretParams = []string{"api_key", "api_sig", "sk"}
+
+// Cassette é um http.RoundTripper que grava ou reproduz as requisições feitas ao
+// Last.fm, com um arquivo JSON por interação. As credenciais são substituídas por
+// REDACTED antes de gravar, e as requisições são identificadas sem elas, de modo
+// que cassetes gravados com uma chave podem ser reproduzidos com qualquer outra.
+type Cassette struct {
+	dir       string
+	mode      CassetteMode
+	transport http.RoundTripper
+}
+
+// interaction é o conteúdo de um arquivo do cassete
+type interaction struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Response struct {
+		StatusCode  int    `json:"statusCode"`
+		ContentType string `json:"contentType,omitempty"`
+		// Corpos JSON são gravados como estão, para facilitar a leitura; os demais como string
+		Body json.RawMessage `json:"body"`
+	} `json:"response"`
+}
+
+// NewCassette cria um cassete no diretório dir. No modo de gravação as requisições
+// são enviadas por transport (http.DefaultTransport se nil).
+func NewCassette(dir string, mode CassetteMode, transport http.RoundTripper) (*Cassette, error) {
+	switch mode {
+	case CassetteRecord:
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("error creating cassette directory: %w", err)
+		}
+	case CassetteReplay:
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("error opening cassette: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q (expected record or replay)", mode)
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Cassette{dir: dir, mode: mode, transport: transport}, nil
+}
+
+// RoundTrip implementa http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestURL := scrubURL(req.URL)
+	file := filepath.Join(c.dir, cassetteFileName(req.Method, req.URL))
+
+	if c.mode == CassetteReplay {
+		return c.replay(req, file, requestURL)
+	}
+	return c.record(req, file, requestURL)
+}
+
+func (c *Cassette) replay(req *http.Request, file, requestURL string) (*http.Response, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("cassette has no recorded interaction for %s %s", req.Method, requestURL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading cassette: %w", err)
+	}
+
+	var recorded interaction
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("error decoding cassette %s: %w", file, err)
+	}
+	body := []byte(recorded.Response.Body)
+	var text string
+	if err := json.Unmarshal(body, &text); err == nil {
+		body = []byte(text)
+	}
+
+	resp := &http.Response{
+		StatusCode:    recorded.Response.StatusCode,
+		Status:        fmt.Sprintf("%d %s", recorded.Response.StatusCode, http.StatusText(recorded.Response.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	if recorded.Response.ContentType != "" {
+		resp.Header.Set("Content-Type", recorded.Response.ContentType)
+	}
+	return resp, nil
+}
+
+func (c *Cassette) record(req *http.Request, file, requestURL string) (*http.Response, error) {
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var recorded interaction
+	recorded.Request.Method = req.Method
+	recorded.Request.URL = requestURL
+	recorded.Response.StatusCode = resp.StatusCode
+	recorded.Response.ContentType = resp.Header.Get("Content-Type")
+	recorded.Response.Body, err = encodeBody(scrubBody(string(body), req.URL))
+	if err != nil {
+		return nil, err
+	}
+
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(recorded); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(file, data.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("error writing cassette: %w", err)
+	}
+	return resp, nil
+}
+
+// encodeBody mantém corpos JSON válidos como estão e codifica os demais como string
+func encodeBody(body string) (json.RawMessage, error) {
+	if json.Valid([]byte(body)) {
+		return json.RawMessage(body), nil
+	}
+	return json.Marshal(body)
+}
+
+// scrubURL retorna a URL da requisição com as credenciais substituídas e a query
+// em ordem canônica
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	scrubbed.RawQuery = scrubQuery(u)
+	return scrubbed.String()
+}
+
+// scrubQuery retorna a query codificada em ordem canônica e sem as credenciais
+func scrubQuery(u *url.URL) string {
+	query := u.Query()
+	for _, param := range secretParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	return query.Encode()
+}
+
+// scrubBody remove do corpo da resposta qualquer credencial enviada na requisição
+func scrubBody(body string, u *url.URL) string {
+	query := u.Query()
+	for _, param := range secretParams {
+		if secret := query.Get(param); secret != "" {
+			body = strings.ReplaceAll(body, secret, redacted)
+		}
+	}
+	return body
+}
+
+// cassetteFileName gera um nome de arquivo estável para a requisição, prefixado
+// pelo método do Last.fm (ex.: "track.search-1a2b3c4d5e6f.json") para facilitar a leitura.
+// Como a API do Last.fm tem um único endpoint, apenas a query identifica a requisição,
+// e os cassetes continuam válidos com outra URL base.
+func cassetteFileName(method string, u *url.URL) string {
+	sum := sha256.Sum256([]byte(method + " " + scrubQuery(u)))
+
+	prefix := "request"
+	if lastfmMethod := u.Query().Get("method"); lastfmMethod != "" {
+		prefix = lastfmMethod
+	}
+	return prefix + "-" + hex.EncodeToString(sum[:6]) + ".json"
+}
@@ -0,0 +1,116 @@
+package lastfm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc permite usar uma função como http.RoundTripper nos testes
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// replayAdapter cria um adaptador que responde com os cassetes de testdata/cassettes,
+// gravados sem as consultas de tags
+func replayAdapter(t *testing.T) *LastFMAdapter {
+	t.Helper()
+	cassette, err := NewCassette(filepath.Join("testdata", "cassettes"), CassetteReplay, nil)
+	require.NoError(t, err)
+	return newTestAdapter(t, "any-key", WithTransport(cassette), WithGenreLookups(false))
+}
+
+func TestLastFMAdapter_SearchFromCassette(t *testing.T) {
+	adapter := replayAdapter(t)
+
+	songs, err := adapter.SearchSongs(context.Background(), "", "cher", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, songs, 4)
+	assert.Equal(t, "cher-believe", songs[0].ID)
+	assert.Equal(t, "Believe", songs[0].Attributes.Name)
+	assert.Equal(t, "Cher", songs[0].Attributes.ArtistName)
+	assert.Equal(t, "hozier-cherry-wine", songs[2].ID)
+
+	albums, err := adapter.SearchAlbums(context.Background(), "", "believe", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, albums, 2)
+	assert.Equal(t, "cher-believe", albums[0].ID)
+	assert.Equal(t, "Believe", albums[0].Attributes.Name)
+	assert.Equal(t, "https://lastfm.freetls.fastly.net/i/u/174s/3b54885952161aaea4ce2965b2db1638.png", albums[0].Attributes.Artwork.URL)
+
+	artists, err := adapter.SearchArtists(context.Background(), "", "cher", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, artists, 2)
+	assert.Equal(t, "cher", artists[0].ID)
+	assert.Equal(t, "cheryl-cole", artists[1].ID)
+}
+
+func TestCassette_ReplayFailsOnUnmatchedRequest(t *testing.T) {
+	adapter := replayAdapter(t)
+
+	_, err := adapter.SearchArtists(context.Background(), "", "madonna", 5, 0)
+	require.Error(t, err)
+	assert.Equal(t, domain.KindUpstream, domain.KindOf(err))
+	assert.Contains(t, err.Error(), "no recorded interaction")
+}
+
+func TestCassette_RecordScrubsCredentials(t *testing.T) {
+	const apiKey = "0123456789abcdef0123456789abcdef"
+	dir := t.TempDir()
+
+	upstream := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, apiKey, req.URL.Query().Get("api_key"))
+		body := `{"results":{"artistmatches":{"artist":[{"name":"Cher","url":"https://www.last.fm/music/Cher?key=` + apiKey + `"}]}}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	recorder, err := NewCassette(dir, CassetteRecord, upstream)
+	require.NoError(t, err)
+
+	recorded, err := newTestAdapter(t, apiKey, WithTransport(recorder), WithGenreLookups(false)).SearchArtists(context.Background(), "", "cher", 5, 0)
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasPrefix(files[0].Name(), "artist.search-"))
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), apiKey)
+	assert.Contains(t, string(data), "api_key=REDACTED")
+
+	// O cassete gravado é reproduzido com outra chave e sem acessar o upstream
+	player, err := NewCassette(dir, CassetteReplay, nil)
+	require.NoError(t, err)
+	replayed, err := newTestAdapter(t, "other-key", WithTransport(player), WithGenreLookups(false)).SearchArtists(context.Background(), "", "cher", 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	// Com as consultas de tags, as requisições que o cassete não tem falham
+	_, err = newTestAdapter(t, "other-key", WithTransport(player)).SearchArtists(context.Background(), "", "cher", 5, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded interaction")
+}
+
+func TestNewCassette_Errors(t *testing.T) {
+	_, err := NewCassette(t.TempDir(), "rewind", nil)
+	assert.Error(t, err)
+
+	_, err = NewCassette(filepath.Join(t.TempDir(), "missing"), CassetteReplay, nil)
+	assert.Error(t, err)
+}
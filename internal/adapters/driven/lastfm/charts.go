@@ -0,0 +1,215 @@
+package lastfm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// lastfmCountries lista os storefronts cujo nome difere do nome do país no
+// ISO 3166-1, que é o formato aceito pelo geo.getTopTracks
+var lastfmCountries = map[string]string{
+	"kr": "Korea, Republic of",
+}
+
+// chartTrack representa uma faixa dos rankings do Last.fm
+type chartTrack struct {
+	Name     string `json:"name"`
+	Duration string `json:"duration"`
+	Artist   struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+	Image []image `json:"image"`
+}
+
+// chartAlbum representa um álbum dos rankings do Last.fm
+type chartAlbum struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Artist struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+	Image []image `json:"image"`
+}
+
+// GetTopSongs retorna as músicas mais tocadas: as do gênero (um ID do catálogo)
+// via tag.getTopTracks, as do país do storefront via geo.getTopTracks ou as do
+// mundo via chart.getTopTracks
+// https://www.last.fm/api/show/tag.getTopTracks
+// https://www.last.fm/api/show/geo.getTopTracks
+// https://www.last.fm/api/show/chart.getTopTracks
+func (a *LastFMAdapter) GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error) {
+	params := url.Values{}
+	var chartGenres []string
+	switch {
+	case genre != "":
+		tagName, g, err := genreTag(genre)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("method", "tag.getTopTracks")
+		params.Set("tag", tagName)
+		chartGenres = domain.GenreNames([]domain.Genre{*g})
+	case storefront != "":
+		country, err := countryName(storefront)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("method", "geo.getTopTracks")
+		params.Set("country", country)
+	default:
+		params.Set("method", "chart.getTopTracks")
+	}
+
+	// As três chamadas retornam as faixas no mesmo formato
+	tracks, err := pageWindow(limit, offset, func(page int) ([]chartTrack, error) {
+		var result struct {
+			Tracks struct {
+				Track flexList[chartTrack] `json:"track"`
+			} `json:"tracks"`
+		}
+		err := a.get(ctx, pageParams(params, limit, page), &result)
+		return result.Tracks.Track, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	songs := make([]domain.Song, 0, len(tracks))
+	for _, track := range tracks {
+		duration, _ := strconv.Atoi(track.Duration)
+		songID := slugify(track.Artist.Name + " " + track.Name)
+		songs = append(songs, domain.Song{
+			ID:   songID,
+			Type: "songs",
+			Attributes: domain.SongAttributes{
+				Name:             track.Name,
+				ArtistName:       track.Artist.Name,
+				DurationInMillis: duration * 1000, // Os rankings retornam a duração em segundos
+				GenreNames:       append([]string(nil), chartGenres...),
+				Artwork: domain.Artwork{
+					URL: largeImageURL(track.Image),
+				},
+				PlayParams: domain.PlayParams{
+					ID:   songID,
+					Kind: "song",
+				},
+			},
+		})
+	}
+
+	// Fora do ranking de um gênero, os gêneros vêm das tags de cada faixa
+	if chartGenres == nil {
+		if err := a.newGenreResolver().songs(ctx, songs); err != nil {
+			return nil, err
+		}
+	}
+	return songs, nil
+}
+
+// GetTopAlbums retorna os álbuns mais tocados do gênero (um ID do catálogo) via
+// tag.getTopAlbums. O Last.fm não tem um ranking geral de álbuns, então sem gênero
+// é usado o da tag mais popular no momento, obtida via chart.getTopTags.
+// https://www.last.fm/api/show/tag.getTopAlbums
+// https://www.last.fm/api/show/chart.getTopTags
+func (a *LastFMAdapter) GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error) {
+	var tagName string
+	var chartGenres []string
+	if genre == "" {
+		var err error
+		if tagName, err = a.topTag(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		var g *domain.Genre
+		var err error
+		if tagName, g, err = genreTag(genre); err != nil {
+			return nil, err
+		}
+		chartGenres = domain.GenreNames([]domain.Genre{*g})
+	}
+
+	params := url.Values{}
+	params.Set("method", "tag.getTopAlbums")
+	params.Set("tag", tagName)
+
+	chartAlbums, err := pageWindow(limit, offset, func(page int) ([]chartAlbum, error) {
+		var result struct {
+			Albums struct {
+				Album flexList[chartAlbum] `json:"album"`
+			} `json:"albums"`
+		}
+		err := a.get(ctx, pageParams(params, limit, page), &result)
+		return result.Albums.Album, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	albums := make([]domain.Album, 0, len(chartAlbums))
+	for _, album := range chartAlbums {
+		albumID := slugify(album.Artist.Name + " " + album.Name)
+		albums = append(albums, domain.Album{
+			ID:   albumID,
+			Type: "albums",
+			Attributes: domain.AlbumAttributes{
+				Name:       album.Name,
+				ArtistName: album.Artist.Name,
+				URL:        album.URL,
+				Artwork: domain.Artwork{
+					URL: largeImageURL(album.Image),
+				},
+				PlayParams: domain.PlayParams{
+					ID:   albumID,
+					Kind: "album",
+				},
+				GenreNames: append([]string(nil), chartGenres...),
+				IsComplete: true,
+			},
+		})
+	}
+
+	// A tag mais popular pode não ser um gênero, então sem gênero os gêneros
+	// vêm das tags de cada álbum
+	if chartGenres == nil {
+		if err := a.newGenreResolver().albums(ctx, albums); err != nil {
+			return nil, err
+		}
+	}
+	return albums, nil
+}
+
+// topTag retorna a tag mais popular do Last.fm
+func (a *LastFMAdapter) topTag(ctx context.Context) (string, error) {
+	params := url.Values{}
+	params.Set("method", "chart.getTopTags")
+	params.Set("limit", "1")
+
+	var result struct {
+		Tags struct {
+			Tag flexList[tag] `json:"tag"`
+		} `json:"tags"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return "", err
+	}
+	if len(result.Tags.Tag) == 0 || result.Tags.Tag[0].Name == "" {
+		return "", domain.UpstreamError("Last.fm returned no top tags", nil)
+	}
+	return result.Tags.Tag[0].Name, nil
+}
+
+// countryName retorna o nome do país do storefront no formato do Last.fm
+func countryName(storefront string) (string, error) {
+	if name, ok := lastfmCountries[storefront]; ok {
+		return name, nil
+	}
+	sf, ok := domain.FindStorefront(storefront)
+	if !ok {
+		return "", domain.NotFound(fmt.Sprintf("storefront %s not found", storefront))
+	}
+	return sf.Attributes.Name, nil
+}
@@ -0,0 +1,135 @@
+package lastfm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var topTracks = []string{
+	`{"name":"Believe","duration":"239","artist":{"name":"Cher"},"image":[{"#text":"https://img/large.png","size":"large"}]}`,
+	`{"name":"Strong Enough","duration":"0","artist":{"name":"Cher"},"image":[]}`,
+	`{"name":"Vogue","duration":"316","artist":{"name":"Madonna"},"image":[]}`,
+}
+
+var topAlbums = []string{
+	`{"name":"Nevermind","url":"https://www.last.fm/music/Nirvana/Nevermind","artist":{"name":"Nirvana"},"image":[]}`,
+	`{"name":"OK Computer","artist":{"name":"Radiohead"},"image":[]}`,
+}
+
+// chartBody responde com a página pedida dos itens de um ranking, paginados
+// pelos parâmetros limit e page como no Last.fm
+func chartBody(q url.Values, format string, items []string) string {
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	page, _ := strconv.Atoi(q.Get("page"))
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	return fmt.Sprintf(format, strings.Join(items[start:end], ","))
+}
+
+func TestLastFMAdapter_GetTopSongs(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if strings.HasSuffix(q.Get("method"), ".getTopTags") {
+			w.Write([]byte(`{"toptags":{"tag":[{"name":"dance"},{"name":"female vocalists"}]}}`))
+			return
+		}
+		queries = append(queries, q.Get("method")+" "+q.Get("country")+q.Get("tag")+" "+q.Get("limit")+" "+q.Get("page"))
+		w.Write([]byte(chartBody(q, `{"tracks":{"track":[%s]}}`, topTracks)))
+	}))
+	defer server.Close()
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	songs, err := adapter.GetTopSongs(ctx, "", "", "", 2, 1)
+	require.NoError(t, err)
+	require.Len(t, songs, 2)
+	assert.Equal(t, "cher-strong-enough", songs[0].ID)
+	assert.Equal(t, "madonna-vogue", songs[1].ID)
+	assert.Equal(t, 316000, songs[1].Attributes.DurationInMillis)
+	assert.Equal(t, []string{"Dance", "Music"}, songs[1].Attributes.GenreNames)
+
+	// Offsets múltiplos do limite precisam de uma única página
+	songs, err = adapter.GetTopSongs(ctx, "", "", "", 1, 2)
+	require.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, "madonna-vogue", songs[0].ID)
+	}
+
+	// Offsets além do ranking, mesmo perto de math.MaxInt, resultam em nenhuma música
+	songs, err = adapter.GetTopSongs(ctx, "", "", "", 2, 4)
+	require.NoError(t, err)
+	assert.Empty(t, songs)
+	songs, err = adapter.GetTopSongs(ctx, "", "", "", 2, math.MaxInt-1)
+	require.NoError(t, err)
+	assert.Empty(t, songs)
+
+	songs, err = adapter.GetTopSongs(ctx, "", "br", "", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, songs, 3)
+	assert.Equal(t, "https://img/large.png", songs[0].Attributes.Artwork.URL)
+
+	_, err = adapter.GetTopSongs(ctx, "", "kr", "", 10, 0)
+	require.NoError(t, err)
+
+	// O gênero tem precedência sobre o país e dispensa as tags de cada faixa
+	songs, err = adapter.GetTopSongs(ctx, "", "br", "18", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hip-Hop/Rap", "Music"}, songs[0].Attributes.GenreNames)
+
+	_, err = adapter.GetTopSongs(ctx, "", "", "999", 10, 0)
+	assert.True(t, domain.KindOf(err) == domain.KindNotFound, err)
+
+	assert.Equal(t, []string{
+		"chart.getTopTracks  2 1",
+		"chart.getTopTracks  2 2",
+		"chart.getTopTracks  1 3",
+		"chart.getTopTracks  2 3",
+		"geo.getTopTracks Brazil 10 1",
+		"geo.getTopTracks Korea, Republic of 10 1",
+		"tag.getTopTracks hip-hop 10 1",
+	}, queries)
+}
+
+func TestLastFMAdapter_GetTopAlbums(t *testing.T) {
+	responses := map[string]string{
+		"chart.getTopTags":  `{"tags":{"tag":[{"name":"seen live"}]}}`,
+		"album.getTopTags":  `{"toptags":{"tag":[{"name":"grunge"},{"name":"90s"}]}}`,
+		"artist.getTopTags": `{"toptags":{"tag":[]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("method") == "tag.getTopAlbums" {
+			w.Write([]byte(chartBody(q, `{"albums":{"album":[%s]}}`, topAlbums)))
+			return
+		}
+		w.Write([]byte(responses[q.Get("method")]))
+	}))
+	defer server.Close()
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	// Sem gênero, o ranking é o da tag mais popular e os gêneros vêm das tags dos álbuns
+	albums, err := adapter.GetTopAlbums(context.Background(), "", "", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, albums, 2)
+	assert.Equal(t, "nirvana-nevermind", albums[0].ID)
+	assert.Equal(t, []string{"Grunge", "Music"}, albums[0].Attributes.GenreNames)
+
+	albums, err = adapter.GetTopAlbums(context.Background(), "", "1003", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, albums, 1)
+	assert.Equal(t, "radiohead-ok-computer", albums[0].ID)
+	assert.Equal(t, []string{"Grunge", "Music"}, albums[0].Attributes.GenreNames)
+}
@@ -0,0 +1,334 @@
+package lastfm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// Número máximo de gêneros atribuídos a um recurso, além do gênero raiz
+const maxGenreNames = 3
+
+// Número máximo de consultas de tags feitas em paralelo para uma mesma resposta
+const maxConcurrentTagLookups = 4
+
+// tagGenres associa as tags do Last.fm, normalizadas por normalizeTag, aos IDs
+// dos gêneros do catálogo. Tags que não são gêneros ("seen live", "80s",
+// "female vocalists") ficam de fora e são ignoradas. Os nomes dos próprios
+// gêneros também são reconhecidos, sem precisar constar aqui.
+var tagGenres = map[string]string{
+	"alternative rock":    "20",
+	"indie":               "1004",
+	"indie pop":           "20",
+	"gothic rock":         "1002",
+	"post punk":           "1005",
+	"punk rock":           "1006",
+	"pop punk":            "1006",
+	"gospel":              "22",
+	"christian":           "22",
+	"dance pop":           "14",
+	"edm":                 "17",
+	"deep house":          "1048",
+	"drum and bass":       "1049",
+	"drum n bass":         "1049",
+	"dnb":                 "1049",
+	"trip hop":            "1057",
+	"idm":                 "1060",
+	"experimental":        "1060",
+	"hip hop":             "18",
+	"hiphop":              "18",
+	"rap":                 "18",
+	"trap":                "18",
+	"gangsta rap":         "1071",
+	"underground hip hop": "1077",
+	"jpop":                "27",
+	"smooth jazz":         "11",
+	"kpop":                "51",
+	"reggaeton":           "12",
+	"heavy metal":         "1153",
+	"thrash metal":        "1153",
+	"death metal":         "1153",
+	"black metal":         "1153",
+	"metalcore":           "1153",
+	"electropop":          "14",
+	"synthpop":            "14",
+	"pop rock":            "1133",
+	"rnb":                 "15",
+	"r&b":                 "15",
+	"r and b":             "15",
+	"neo soul":            "1141",
+	"dancehall":           "24",
+	"classic rock":        "21",
+	"blues rock":          "1147",
+	"progressive rock":    "1155",
+	"psychedelic rock":    "1156",
+	"rock n roll":         "1157",
+	"rock and roll":       "1157",
+	"singer songwriter":   "10",
+	"acoustic":            "10",
+	"folk":                "1063",
+	"folk rock":           "1065",
+	"score":               "16",
+}
+
+// genreTagOverrides lista as tags usadas nos rankings dos gêneros cujo nome não
+// é uma tag popular do Last.fm; os demais usam o próprio nome
+var genreTagOverrides = map[string]string{
+	"22":   "gospel",
+	"1049": "drum and bass",
+	"1060": "idm",
+	"18":   "hip-hop",
+	"15":   "rnb",
+	"1136": "rnb",
+	"1155": "progressive rock",
+	"1157": "rock n roll",
+	"1147": "blues rock",
+	"1065": "folk rock",
+	"1133": "pop rock",
+	"10":   "singer-songwriter",
+}
+
+// genresByTag indexa os gêneros pelas tags de tagGenres e pelos seus nomes normalizados
+var genresByTag = func() map[string]domain.Genre {
+	index := make(map[string]domain.Genre)
+	for _, g := range domain.Genres() {
+		index[normalizeTag(g.Attributes.Name)] = g
+	}
+	for name, id := range tagGenres {
+		g, ok := domain.FindGenre(id)
+		if !ok {
+			panic(fmt.Sprintf("lastfm: tag %q maps to unknown genre %s", name, id))
+		}
+		index[name] = *g
+	}
+	return index
+}()
+
+// normalizeTag padroniza uma tag do Last.fm: minúsculas, com hífens e
+// sublinhados trocados por espaços (ex.: "Hip-Hop" e "hip_hop" viram "hip hop")
+func normalizeTag(name string) string {
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(name))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// genresOf converte as principais tags do Last.fm nos gêneros do catálogo,
+// na ordem das tags e ignorando as que não são gêneros
+func genresOf(tags []tag) []domain.Genre {
+	var genres []domain.Genre
+	seen := make(map[string]bool)
+	for _, t := range tags {
+		if len(genres) >= maxGenreNames {
+			break
+		}
+		g, ok := genresByTag[normalizeTag(t.Name)]
+		if !ok || seen[g.ID] {
+			continue
+		}
+		seen[g.ID] = true
+		genres = append(genres, g)
+	}
+	return genres
+}
+
+// genreNames converte as principais tags do Last.fm nos genreNames de um recurso
+func genreNames(tags []tag) []string {
+	return domain.GenreNames(genresOf(tags))
+}
+
+// genreTag retorna a tag do Last.fm usada no ranking do gênero com o ID informado
+func genreTag(id string) (string, *domain.Genre, error) {
+	g, ok := domain.FindGenre(id)
+	if !ok {
+		return "", nil, domain.NotFound(fmt.Sprintf("genre %s not found", id))
+	}
+	if tag, ok := genreTagOverrides[id]; ok {
+		return tag, g, nil
+	}
+	return strings.ToLower(g.Attributes.Name), g, nil
+}
+
+// genreResolver atribui os gêneros dos recursos cujas respostas não trazem tags,
+// como os resultados de buscas e rankings. Com WithGenreLookups os gêneros são
+// consultados via getTopTags, uma requisição por recurso, e os recursos sem tags de
+// gênero recebem os gêneros do artista, consultados uma única vez por resposta.
+// Sem a opção esses recursos recebem apenas o gênero raiz.
+// https://www.last.fm/api/show/track.getTopTags
+// https://www.last.fm/api/show/album.getTopTags
+// https://www.last.fm/api/show/artist.getTopTags
+type genreResolver struct {
+	adapter *LastFMAdapter
+
+	mu      sync.Mutex
+	artists map[string]*artistGenres
+}
+
+// artistGenres guarda os gêneros de um artista, consultados uma única vez
+type artistGenres struct {
+	once   sync.Once
+	genres []domain.Genre
+	err    error
+}
+
+func (a *LastFMAdapter) newGenreResolver() *genreResolver {
+	return &genreResolver{adapter: a, artists: make(map[string]*artistGenres)}
+}
+
+// withArtist registra os gêneros já conhecidos do artista, para que não sejam consultados
+func (r *genreResolver) withArtist(artistName string, genres []domain.Genre) *genreResolver {
+	entry := &artistGenres{genres: genres}
+	entry.once.Do(func() {})
+	r.artists[artistName] = entry
+	return r
+}
+
+// songs atribui os genreNames das músicas
+func (r *genreResolver) songs(ctx context.Context, songs []domain.Song) error {
+	return r.each(len(songs), func(i int) (err error) {
+		songs[i].Attributes.GenreNames, err = r.track(ctx, songs[i].Attributes.ArtistName, songs[i].Attributes.Name)
+		return err
+	})
+}
+
+// albums atribui os genreNames dos álbuns
+func (r *genreResolver) albums(ctx context.Context, albums []domain.Album) error {
+	return r.each(len(albums), func(i int) (err error) {
+		albums[i].Attributes.GenreNames, err = r.album(ctx, albums[i].Attributes.ArtistName, albums[i].Attributes.Name)
+		return err
+	})
+}
+
+// artistList atribui os genreNames dos artistas
+func (r *genreResolver) artistList(ctx context.Context, artists []domain.Artist) error {
+	return r.each(len(artists), func(i int) error {
+		genres, err := r.artistGenres(ctx, artists[i].Attributes.Name)
+		artists[i].Attributes.GenreNames = domain.GenreNames(genres)
+		return err
+	})
+}
+
+// each executa resolve para cada índice de 0 a n-1, com no máximo
+// maxConcurrentTagLookups execuções simultâneas, e retorna o primeiro erro.
+// Sem consultas de tags os recursos recebem apenas o gênero raiz.
+func (r *genreResolver) each(n int, resolve func(i int) error) error {
+	if !r.adapter.genreLookups {
+		for i := 0; i < n; i++ {
+			if err := resolve(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentTagLookups)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = resolve(i)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// track retorna os genreNames de uma faixa
+func (r *genreResolver) track(ctx context.Context, artistName, trackName string) ([]string, error) {
+	if !r.adapter.genreLookups {
+		return domain.GenreNames(nil), nil
+	}
+	params := url.Values{}
+	params.Set("method", "track.getTopTags")
+	params.Set("artist", artistName)
+	params.Set("track", trackName)
+	tags, err := r.adapter.topTags(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.orArtist(ctx, genresOf(tags), artistName)
+}
+
+// album retorna os genreNames de um álbum
+func (r *genreResolver) album(ctx context.Context, artistName, albumName string) ([]string, error) {
+	if !r.adapter.genreLookups {
+		return domain.GenreNames(nil), nil
+	}
+	params := url.Values{}
+	params.Set("method", "album.getTopTags")
+	params.Set("artist", artistName)
+	params.Set("album", albumName)
+	tags, err := r.adapter.topTags(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.orArtist(ctx, genresOf(tags), artistName)
+}
+
+// orArtist retorna os genreNames dos gêneros informados, que vêm das tags de
+// uma resposta, ou, se não houver nenhum e as consultas estiverem habilitadas,
+// os do artista
+func (r *genreResolver) orArtist(ctx context.Context, genres []domain.Genre, artistName string) ([]string, error) {
+	if len(genres) == 0 && artistName != "" {
+		var err error
+		if genres, err = r.artistGenres(ctx, artistName); err != nil {
+			return nil, err
+		}
+	}
+	return domain.GenreNames(genres), nil
+}
+
+// artistGenres retorna os gêneros do artista, ou nenhum sem consultas de tags
+func (r *genreResolver) artistGenres(ctx context.Context, artistName string) ([]domain.Genre, error) {
+	if !r.adapter.genreLookups {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	entry, ok := r.artists[artistName]
+	if !ok {
+		entry = &artistGenres{}
+		r.artists[artistName] = entry
+	}
+	r.mu.Unlock()
+
+	entry.once.Do(func() {
+		params := url.Values{}
+		params.Set("method", "artist.getTopTags")
+		params.Set("artist", artistName)
+		var tags []tag
+		tags, entry.err = r.adapter.topTags(ctx, params)
+		entry.genres = genresOf(tags)
+	})
+	return entry.genres, entry.err
+}
+
+// topTags consulta um dos métodos getTopTags. Um recurso que o Last.fm não
+// conhece resulta em nenhuma tag; os demais erros são retornados.
+func (a *LastFMAdapter) topTags(ctx context.Context, params url.Values) ([]tag, error) {
+	params.Set("autocorrect", "1")
+
+	var result struct {
+		TopTags struct {
+			Tag flexList[tag] `json:"tag"`
+		} `json:"toptags"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		if domain.KindOf(notFoundOr(err)) == domain.KindNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result.TopTags.Tag, nil
+}
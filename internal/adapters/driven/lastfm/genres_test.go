@@ -0,0 +1,126 @@
+package lastfm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenreNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{"Genre names", []string{"rock", "Alternative"}, []string{"Rock", "Alternative", "Music"}},
+		{"Spelling variants", []string{"Hip-Hop", "hip_hop", "RnB"}, []string{"Hip-Hop/Rap", "R&B/Soul", "Music"}},
+		{"Subgenres", []string{"grunge", "heavy metal", "k-pop"}, []string{"Grunge", "Metal", "K-Pop", "Music"}},
+		{"Non-genre tags", []string{"seen live", "80s", "female vocalists"}, []string{"Music"}},
+		{"At most three genres", []string{"pop", "rock", "jazz", "blues"}, []string{"Pop", "Rock", "Jazz", "Music"}},
+		{"No tags", nil, []string{"Music"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags []tag
+			for _, name := range tt.tags {
+				tags = append(tags, tag{Name: name})
+			}
+			assert.Equal(t, tt.expected, genreNames(tags))
+		})
+	}
+}
+
+func TestTagGenresReferToKnownGenres(t *testing.T) {
+	for name, id := range tagGenres {
+		_, ok := domain.FindGenre(id)
+		assert.True(t, ok, "tag %q maps to unknown genre %s", name, id)
+	}
+	for id := range genreTagOverrides {
+		_, ok := domain.FindGenre(id)
+		assert.True(t, ok, "unknown genre %s", id)
+	}
+}
+
+func TestLastFMAdapter_SearchGenres(t *testing.T) {
+	var artistLookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch q.Get("method") {
+		case "album.search":
+			w.Write([]byte(`{"results":{"albummatches":{"album":[
+				{"name":"Believe","artist":"Cher"},
+				{"name":"Closer","artist":"Cher"},
+				{"name":"Ray of Light","artist":"Madonna"}
+			]}}}`))
+		case "album.getTopTags":
+			if q.Get("album") == "Ray of Light" {
+				w.Write([]byte(`{"toptags":{"tag":[{"name":"electronic"},{"name":"trip-hop"}]}}`))
+				return
+			}
+			w.Write([]byte(`{"toptags":{"tag":[{"name":"favorite albums"}]}}`))
+		case "artist.getTopTags":
+			artistLookups.Add(1)
+			w.Write([]byte(`{"toptags":{"tag":[{"name":"pop"},{"name":"dance"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	albums, err := adapter.SearchAlbums(context.Background(), "", "believe", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, albums, 3)
+
+	// Álbuns sem tags de gênero recebem os gêneros do artista, consultados uma única vez
+	assert.Equal(t, []string{"Pop", "Dance", "Music"}, albums[0].Attributes.GenreNames)
+	assert.Equal(t, []string{"Pop", "Dance", "Music"}, albums[1].Attributes.GenreNames)
+	assert.Equal(t, []string{"Electronic", "Downtempo", "Music"}, albums[2].Attributes.GenreNames)
+	assert.Equal(t, int32(1), artistLookups.Load())
+}
+
+func TestLastFMAdapter_SearchGenresWithoutLookups(t *testing.T) {
+	server := fakeLastFM(t, map[string]string{
+		"album.search": `{"results":{"albummatches":{"album":[{"name":"Believe","artist":"Cher"}]}}}`,
+	})
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL), WithGenreLookups(false))
+	assert.False(t, adapter.GenreLookups())
+
+	// Sem consultas de tags a busca faz uma única requisição
+	albums, err := adapter.SearchAlbums(context.Background(), "", "believe", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, albums, 1)
+	assert.Equal(t, []string{"Music"}, albums[0].Attributes.GenreNames)
+}
+
+func TestLastFMAdapter_SearchGenresUnknownArtist(t *testing.T) {
+	server := fakeLastFM(t, map[string]string{
+		"artist.search":     `{"results":{"artistmatches":{"artist":[{"name":"Cher"}]}}}`,
+		"artist.getTopTags": `{"error":6,"message":"The artist you supplied could not be found"}`,
+	})
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	artists, err := adapter.SearchArtists(context.Background(), "", "cher", 5, 0)
+	require.NoError(t, err)
+	require.Len(t, artists, 1)
+	assert.Equal(t, []string{"Music"}, artists[0].Attributes.GenreNames)
+}
+
+func TestLastFMAdapter_SearchGenresTagErrors(t *testing.T) {
+	server := fakeLastFM(t, map[string]string{
+		"artist.search":     `{"results":{"artistmatches":{"artist":[{"name":"Cher"}]}}}`,
+		"artist.getTopTags": `{"error":29,"message":"Rate Limit Exceeded"}`,
+	})
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	_, err := adapter.SearchArtists(context.Background(), "", "cher", 5, 0)
+	assert.Equal(t, domain.KindRateLimited, domain.KindOf(err))
+}
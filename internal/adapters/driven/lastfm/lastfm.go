@@ -2,18 +2,19 @@ package lastfm
 
 import (
 	"applemusic-api-simulator/internal/core/domain"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math"
 	"net/http"
 	"net/url"
-	"os"
 	"strconv"
 	"strings"
 )
 
-const lastfmBaseURL = "https://ws.audioscrobbler.com/2.0/"
-
 // LastFMAdapter implementa a interface MusicProvider
 // Usa a API do Last.fm para buscar músicas, álbuns e artistas
 // https://www.last.fm/api/show/track.search
@@ -22,60 +23,66 @@ const lastfmBaseURL = "https://ws.audioscrobbler.com/2.0/"
 type LastFMAdapter struct {
 	apiKey    string
 	apiSecret string
+	baseURL   string
 	client    *http.Client
+
+	genreLookups bool
 }
 
-func NewLastFMAdapter() (*LastFMAdapter, error) {
-	apiKey := os.Getenv("LASTFM_API_KEY")
-	apiSecret := os.Getenv("LASTFM_API_SECRET")
+// NewLastFMAdapter cria o adaptador configurado pelas opções informadas.
+// As credenciais são obrigatórias (WithCredentials); os demais valores têm padrões
+// para a API pública do Last.fm.
+func NewLastFMAdapter(opts ...Option) (*LastFMAdapter, error) {
+	o := options{baseURL: DefaultBaseURL, genreLookups: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-	if apiKey == "" || apiSecret == "" {
-		return nil, fmt.Errorf("LASTFM_API_KEY and LASTFM_API_SECRET environment variables are required")
+	if o.apiKey == "" || o.apiSecret == "" {
+		return nil, fmt.Errorf("Last.fm API key and secret are required")
+	}
+	if _, err := url.ParseRequestURI(o.baseURL); err != nil {
+		return nil, fmt.Errorf("invalid Last.fm base URL: %w", err)
 	}
 
 	return &LastFMAdapter{
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		client:    &http.Client{},
+		apiKey:    o.apiKey,
+		apiSecret: o.apiSecret,
+		baseURL:   o.baseURL,
+		client:    o.httpClient(),
+
+		genreLookups: o.genreLookups,
 	}, nil
 }
 
-func (a *LastFMAdapter) SearchSongs(query string, limit, offset int) ([]domain.Song, error) {
-	// Construir URL da API
-	url := fmt.Sprintf("%s?method=track.search&track=%s&api_key=%s&format=json&limit=%d&page=%d",
-		lastfmBaseURL,
-		url.QueryEscape(query),
-		a.apiKey,
-		limit*2, // Buscar mais resultados para ter mais opções para filtrar
-		offset/limit+1)
-
-	// Fazer requisição
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("error making request to Last.fm: %v", err)
-	}
-	defer resp.Body.Close()
+// GenreLookups informa se os gêneros dos resultados de buscas, rankings e álbuns
+// de artistas são consultados (veja WithGenreLookups)
+func (a *LastFMAdapter) GenreLookups() bool {
+	return a.genreLookups
+}
 
-	// Ler corpo da resposta
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
+func (a *LastFMAdapter) SearchSongs(ctx context.Context, language, query string, limit, offset int) ([]domain.Song, error) {
+	params := url.Values{}
+	params.Set("method", "track.search")
+	params.Set("track", query)
 
-	// Decodificar resposta
-	var result struct {
-		Results struct {
-			TrackMatches struct {
-				Track []struct {
-					Name   string `json:"name"`
-					Artist string `json:"artist"`
-				} `json:"track"`
-			} `json:"trackmatches"`
-		} `json:"results"`
+	type trackMatch struct {
+		Name   string `json:"name"`
+		Artist string `json:"artist"`
 	}
-
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	tracks, err := pageWindow(limit, offset, func(page int) ([]trackMatch, error) {
+		var result struct {
+			Results struct {
+				TrackMatches struct {
+					Track []trackMatch `json:"track"`
+				} `json:"trackmatches"`
+			} `json:"results"`
+		}
+		err := a.get(ctx, pageParams(params, limit, page), &result)
+		return result.Results.TrackMatches.Track, err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Converter para domínio
@@ -88,7 +95,7 @@ func (a *LastFMAdapter) SearchSongs(query string, limit, offset int) ([]domain.S
 	var exactMatches []domain.Song
 	var otherMatches []domain.Song
 
-	for _, track := range result.Results.TrackMatches.Track {
+	for _, track := range tracks {
 		trackName := strings.ToLower(track.Name)
 		artistName := strings.ToLower(track.Artist)
 
@@ -110,10 +117,7 @@ func (a *LastFMAdapter) SearchSongs(query string, limit, offset int) ([]domain.S
 			}
 		}
 
-		songID := fmt.Sprintf("%s-%s", strings.ToLower(track.Artist), strings.ToLower(track.Name))
-		songID = strings.ReplaceAll(songID, " ", "-")
-		songID = strings.ReplaceAll(songID, "/", "-")
-		songID = strings.ReplaceAll(songID, "\\", "-")
+		songID := slugify(track.Artist + " " + track.Name)
 
 		song := domain.Song{
 			ID:   songID,
@@ -121,7 +125,6 @@ func (a *LastFMAdapter) SearchSongs(query string, limit, offset int) ([]domain.S
 			Attributes: domain.SongAttributes{
 				Name:       track.Name,
 				ArtistName: track.Artist,
-				GenreNames: []string{"Pop"},
 			},
 		}
 
@@ -136,39 +139,20 @@ func (a *LastFMAdapter) SearchSongs(query string, limit, offset int) ([]domain.S
 	songs = append(songs, exactMatches...)
 	songs = append(songs, otherMatches...)
 
-	// Limitar ao número solicitado
-	if len(songs) > limit {
-		songs = songs[:limit]
+	if err := a.newGenreResolver().songs(ctx, songs); err != nil {
+		return nil, err
 	}
-
 	return songs, nil
 }
 
-func (a *LastFMAdapter) SearchAlbums(term string, limit, offset int) ([]domain.Album, error) {
-	// Construir URL da API
-	baseURL := "http://ws.audioscrobbler.com/2.0/"
+func (a *LastFMAdapter) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
 	params := url.Values{}
 	params.Set("method", "album.search")
 	params.Set("album", term)
-	params.Set("api_key", a.apiKey)
-	params.Set("format", "json")
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("page", strconv.Itoa(offset/limit+1))
 
-	// Fazer requisição
-	resp, err := a.client.Get(baseURL + "?" + params.Encode())
-	if err != nil {
-		return nil, fmt.Errorf("error making request to Last.fm: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Ler resposta
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
-	}
-
-	// Decodificar resposta
+	// Consultar o Last.fm e decodificar a resposta
 	var result struct {
 		Results struct {
 			AlbumMatches struct {
@@ -186,8 +170,8 @@ func (a *LastFMAdapter) SearchAlbums(term string, limit, offset int) ([]domain.A
 		} `json:"results"`
 	}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	if err := a.get(ctx, params, &result); err != nil {
+		return nil, err
 	}
 
 	// Converter para domínio
@@ -203,15 +187,11 @@ func (a *LastFMAdapter) SearchAlbums(term string, limit, offset int) ([]domain.A
 		}
 
 		// Gerar ID único baseado no nome e artista
-		id := fmt.Sprintf("%s-%s", strings.ToLower(album.Artist), strings.ToLower(album.Name))
-		id = strings.ReplaceAll(id, " ", "-")
-		id = strings.ReplaceAll(id, "/", "-")
-		id = strings.ReplaceAll(id, "\\", "-")
+		id := slugify(album.Artist + " " + album.Name)
 
 		album := domain.Album{
 			ID:   id,
 			Type: "albums",
-			Href: fmt.Sprintf("/v1/catalog/us/albums/%s", id),
 			Attributes: domain.AlbumAttributes{
 				Name:       album.Name,
 				ArtistName: album.Artist,
@@ -222,7 +202,6 @@ func (a *LastFMAdapter) SearchAlbums(term string, limit, offset int) ([]domain.A
 					ID:   id,
 					Kind: "album",
 				},
-				GenreNames: []string{"Pop"},
 				IsComplete: true,
 			},
 		}
@@ -234,34 +213,20 @@ func (a *LastFMAdapter) SearchAlbums(term string, limit, offset int) ([]domain.A
 		}
 	}
 
+	if err := a.newGenreResolver().albums(ctx, albums); err != nil {
+		return nil, err
+	}
 	return albums, nil
 }
 
-func (a *LastFMAdapter) SearchArtists(term string, limit, offset int) ([]domain.Artist, error) {
-	// Construir URL da API
-	baseURL := "http://ws.audioscrobbler.com/2.0/"
+func (a *LastFMAdapter) SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error) {
 	params := url.Values{}
 	params.Set("method", "artist.search")
 	params.Set("artist", term)
-	params.Set("api_key", a.apiKey)
-	params.Set("format", "json")
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("page", strconv.Itoa(offset/limit+1))
 
-	// Fazer requisição
-	resp, err := a.client.Get(baseURL + "?" + params.Encode())
-	if err != nil {
-		return nil, fmt.Errorf("error making request to Last.fm: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Ler resposta
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
-	}
-
-	// Decodificar resposta
+	// Consultar o Last.fm e decodificar a resposta
 	var result struct {
 		Results struct {
 			ArtistMatches struct {
@@ -278,8 +243,8 @@ func (a *LastFMAdapter) SearchArtists(term string, limit, offset int) ([]domain.
 		} `json:"results"`
 	}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	if err := a.get(ctx, params, &result); err != nil {
+		return nil, err
 	}
 
 	// Converter para domínio
@@ -295,18 +260,13 @@ func (a *LastFMAdapter) SearchArtists(term string, limit, offset int) ([]domain.
 		}
 
 		// Gerar ID único baseado no nome
-		id := strings.ToLower(artist.Name)
-		id = strings.ReplaceAll(id, " ", "-")
-		id = strings.ReplaceAll(id, "/", "-")
-		id = strings.ReplaceAll(id, "\\", "-")
+		id := slugify(artist.Name)
 
 		artist := domain.Artist{
 			ID:   id,
 			Type: "artists",
-			Href: fmt.Sprintf("/v1/catalog/us/artists/%s", id),
 			Attributes: domain.ArtistAttributes{
-				Name:       artist.Name,
-				GenreNames: []string{"Pop"},
+				Name: artist.Name,
 				Artwork: domain.Artwork{
 					URL: artworkURL,
 				},
@@ -320,9 +280,208 @@ func (a *LastFMAdapter) SearchArtists(term string, limit, offset int) ([]domain.
 		}
 	}
 
+	if err := a.newGenreResolver().artistList(ctx, artists); err != nil {
+		return nil, err
+	}
 	return artists, nil
 }
 
+// slugify gera o ID usado no catálogo a partir de um nome do Last.fm
+func slugify(name string) string {
+	id := strings.ToLower(name)
+	id = strings.ReplaceAll(id, " ", "-")
+	id = strings.ReplaceAll(id, "/", "-")
+	id = strings.ReplaceAll(id, "\\", "-")
+	return id
+}
+
+// unslugify converte um ID do catálogo de volta em um termo de busca
+func unslugify(id string) string {
+	return strings.ReplaceAll(id, "-", " ")
+}
+
+// get executa uma chamada à API do Last.fm e decodifica o JSON em out.
+// Erros retornados pelo Last.fm no corpo da resposta são embrulhados em erros do domínio
+// e continuam acessíveis como *apiError via errors.As.
+func (a *LastFMAdapter) get(ctx context.Context, params url.Values, out interface{}) error {
+	params.Set("api_key", a.apiKey)
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return domain.UpstreamError("error creating Last.fm request", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		// O erro de url.Error inclui a URL, que não deve expor a chave da API
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+				urlErr.URL = scrubURL(u)
+			}
+			// Prazo da requisição ou timeout do cliente HTTP
+			if urlErr.Timeout() {
+				return domain.Timeout("Last.fm request timed out", err)
+			}
+		}
+		return domain.UpstreamError("error making request to Last.fm", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return domain.UpstreamError("error reading response body", err)
+	}
+
+	var apiErr apiError
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Code != 0 {
+		if apiErr.Code == lastfmErrRateLimitExceeded {
+			return domain.RateLimited("Last.fm rate limit exceeded")
+		}
+		return domain.UpstreamError("Last.fm request failed", &apiErr)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return domain.UpstreamError("Last.fm request failed", fmt.Errorf("unexpected status %s", resp.Status))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return domain.UpstreamError("error decoding response", err)
+	}
+	return nil
+}
+
+// setLang define o idioma dos textos (wiki e biografia) retornados pelo Last.fm,
+// que aceita apenas o código ISO 639 sem a região (ex.: "pt" para "pt-BR")
+func setLang(params url.Values, language string) {
+	if language == "" {
+		return
+	}
+	lang, _, _ := strings.Cut(language, "-")
+	params.Set("lang", strings.ToLower(lang))
+}
+
+// apiError representa um erro retornado no corpo de uma resposta do Last.fm
+// https://www.last.fm/api/errorcodes
+type apiError struct {
+	Code    int    `json:"error"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("Last.fm error %d: %s", e.Code, e.Message)
+}
+
+// Códigos de erro do Last.fm
+const (
+	// Parâmetros inválidos (ex.: faixa, álbum ou artista inexistente)
+	lastfmErrInvalidParameters = 6
+	// Limite de requisições excedido
+	lastfmErrRateLimitExceeded = 29
+)
+
+// image representa uma imagem retornada pelo Last.fm
+type image struct {
+	Size string `json:"size"`
+	URL  string `json:"#text"`
+}
+
+// largeImageURL retorna a URL da imagem de tamanho "large", se existir
+func largeImageURL(images []image) string {
+	for _, img := range images {
+		if img.Size == "large" {
+			return img.URL
+		}
+	}
+	return ""
+}
+
+// tag representa uma tag retornada pelo Last.fm
+type tag struct {
+	Name string `json:"name"`
+}
+
+// tags representa o bloco de tags do Last.fm, que vem como string vazia quando não há tags
+type tags struct {
+	Tag flexList[tag] `json:"tag"`
+}
+
+func (t *tags) UnmarshalJSON(data []byte) error {
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		return nil
+	}
+	type plain tags
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// stripReadMore remove o link "Read more on Last.fm" anexado aos textos do wiki
+func stripReadMore(text string) string {
+	if i := strings.Index(text, "<a href"); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// pageWindow retorna os itens de offset a offset+limit de uma lista paginada do
+// Last.fm, como buscas e rankings. O Last.fm pagina por número de página, então
+// são buscadas as páginas de tamanho limit que cobrem o intervalo, no máximo
+// duas, e os itens são recortados aqui.
+func pageWindow[T any](limit, offset int, fetch func(page int) ([]T, error)) ([]T, error) {
+	// Nenhuma lista chega perto desses offsets, e as páginas não caberiam em um int
+	if offset > math.MaxInt-2*limit {
+		return []T{}, nil
+	}
+
+	skip := offset % limit
+	var items []T
+	for page := offset/limit + 1; len(items) < skip+limit; page++ {
+		pageItems, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems[:min(len(pageItems), limit)]...)
+		if len(pageItems) < limit {
+			break
+		}
+	}
+	if skip >= len(items) {
+		return []T{}, nil
+	}
+	return items[skip:min(len(items), skip+limit)], nil
+}
+
+// pageParams retorna uma cópia dos parâmetros com a página informada
+func pageParams(params url.Values, limit, page int) url.Values {
+	paged := maps.Clone(params)
+	paged.Set("limit", strconv.Itoa(limit))
+	paged.Set("page", strconv.Itoa(page))
+	return paged
+}
+
+// flexList decodifica listas do Last.fm, que vêm como objeto quando há um único
+// item e como string vazia quando não há nenhum
+type flexList[T any] []T
+
+func (l *flexList[T]) UnmarshalJSON(data []byte) error {
+	switch strings.TrimSpace(string(data))[0] {
+	case '[':
+		var items []T
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		*l = items
+	case '{':
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		*l = flexList[T]{item}
+	default:
+		*l = nil
+	}
+	return nil
+}
+
 // Implementações vazias para os outros tipos de busca
 func (a *LastFMAdapter) searchPlaylists(term string, limit, offset int) ([]domain.Playlist, error) {
 	// Last.fm não tem API para playlists, retornando lista vazia
@@ -0,0 +1,351 @@
+package lastfm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAdapter cria um adaptador com credenciais de teste e as opções informadas
+func newTestAdapter(t *testing.T, apiKey string, opts ...Option) *LastFMAdapter {
+	t.Helper()
+	adapter, err := NewLastFMAdapter(append([]Option{WithCredentials(apiKey, "test-secret")}, opts...)...)
+	require.NoError(t, err)
+	return adapter
+}
+
+// fakeLastFM simula a API do Last.fm respondendo a cada método com o corpo informado
+func fakeLastFM(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+
+		body, ok := responses[r.URL.Query().Get("method")]
+		if !ok {
+			t.Errorf("unexpected Last.fm method %q", r.URL.Query().Get("method"))
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+const albumSearchBody = `{"results":{"albummatches":{"album":[
+	{"name":"Believe","artist":"Justin Bieber","url":"https://www.last.fm/music/Justin+Bieber/Believe","image":[]},
+	{"name":"Believe","artist":"Cher","url":"https://www.last.fm/music/Cher/Believe","image":[]}
+]}}}`
+
+const albumInfoBody = `{"album":{
+	"name":"Believe","artist":"Cher","url":"https://www.last.fm/music/Cher/Believe",
+	"image":[{"#text":"https://img/small.png","size":"small"},{"#text":"https://img/large.png","size":"large"}],
+	"tracks":{"track":[
+		{"name":"Believe","duration":239,"@attr":{"rank":1},"artist":{"name":"Cher"}},
+		{"name":"The Power","duration":236,"@attr":{"rank":2},"artist":{"name":"Cher"}}
+	]},
+	"tags":{"tag":[{"name":"pop"},{"name":"dance pop"},{"name":"90s"},{"name":"disco"},{"name":"cher"}]},
+	"wiki":{"summary":"Believe is the twenty-second album. <a href=\"https://www.last.fm/music/Cher/Believe\">Read more on Last.fm</a>","content":"Full text."}
+}}`
+
+func TestLastFMAdapter_SearchSongsPaging(t *testing.T) {
+	tracks := []string{
+		`{"name":"Believe","artist":"Cher"}`,
+		`{"name":"Strong Enough","artist":"Cher"}`,
+		`{"name":"Vogue","artist":"Madonna"}`,
+		`{"name":"If I Could Turn Back Time","artist":"Cher"}`,
+	}
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		pages = append(pages, q.Get("limit")+"/"+q.Get("page"))
+		w.Write([]byte(chartBody(q, `{"results":{"trackmatches":{"track":[%s]}}}`, tracks)))
+	}))
+	defer server.Close()
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL), WithGenreLookups(false))
+
+	// As páginas do Last.fm têm o tamanho de limit, e o intervalo pedido é recortado delas
+	var ids []string
+	for offset := 0; offset < len(tracks); offset += 3 {
+		songs, err := adapter.SearchSongs(context.Background(), "", "cher", 3, offset)
+		require.NoError(t, err)
+		for _, song := range songs {
+			ids = append(ids, song.ID)
+		}
+	}
+	assert.Equal(t, []string{"cher-believe", "cher-strong-enough", "madonna-vogue", "cher-if-i-could-turn-back-time"}, ids,
+		"each track appears once across pages")
+	assert.Equal(t, []string{"3/1", "3/2"}, pages)
+
+	pages = nil
+	songs, err := adapter.SearchSongs(context.Background(), "", "cher", 2, 1)
+	require.NoError(t, err)
+	if assert.Len(t, songs, 2) {
+		assert.Equal(t, "cher-strong-enough", songs[0].ID)
+		assert.Equal(t, "madonna-vogue", songs[1].ID)
+	}
+	assert.Equal(t, []string{"2/1", "2/2"}, pages)
+}
+
+func TestLastFMAdapter_GetAlbum(t *testing.T) {
+	server := fakeLastFM(t, map[string]string{
+		"album.search":  albumSearchBody,
+		"album.getInfo": albumInfoBody,
+	})
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	album, err := adapter.GetAlbum(context.Background(), "pt-BR", "cher-believe")
+	require.NoError(t, err)
+	assert.Equal(t, "cher-believe", album.ID)
+	assert.Equal(t, "Believe", album.Attributes.Name)
+	assert.Equal(t, "Cher", album.Attributes.ArtistName)
+	assert.Equal(t, "https://img/large.png", album.Attributes.Artwork.URL)
+	// "dance pop" também é Pop e "90s" não é um gênero
+	assert.Equal(t, []string{"Pop", "Disco", "Music"}, album.Attributes.GenreNames)
+	assert.Equal(t, 2, album.Attributes.TrackCount)
+	assert.Equal(t, "Believe is the twenty-second album.", album.Attributes.EditorialNotes.Short)
+
+	tracks, err := adapter.GetAlbumTracks(context.Background(), "", "cher-believe")
+	require.NoError(t, err)
+	require.Len(t, tracks, 2)
+	assert.Equal(t, "cher-the-power", tracks[1].ID)
+	assert.Equal(t, 2, tracks[1].Attributes.TrackNumber)
+	assert.Equal(t, 236000, tracks[1].Attributes.DurationInMillis)
+	assert.Equal(t, []string{"Pop", "Disco", "Music"}, tracks[1].Attributes.GenreNames)
+}
+
+// topAlbumsServer simula o artist.getTopAlbums paginado sobre os nomes informados,
+// onde "(null)" representa as entradas de faixas avulsas. O mapa retornado conta
+// as requisições de cada método.
+func topAlbumsServer(t *testing.T, names []string) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		mu.Lock()
+		requests[query.Get("method")]++
+		mu.Unlock()
+
+		switch query.Get("method") {
+		case "artist.search":
+			w.Write([]byte(`{"results":{"artistmatches":{"artist":[{"name":"Cher"}]}}}`))
+		case "artist.getInfo":
+			w.Write([]byte(`{"artist":{"name":"Cher","tags":{"tag":[{"name":"pop"},{"name":"disco"}]}}}`))
+		case "album.getTopTags", "artist.getTopTags":
+			w.Write([]byte(`{"toptags":{"tag":[]}}`))
+		case "artist.getTopAlbums":
+			limit, _ := strconv.Atoi(query.Get("limit"))
+			page, _ := strconv.Atoi(query.Get("page"))
+			start, end := min((page-1)*limit, len(names)), min(page*limit, len(names))
+			albums := make([]string, 0, end-start)
+			for _, name := range names[start:end] {
+				albums = append(albums, fmt.Sprintf(`{"name":%q,"artist":{"name":"Cher"}}`, name))
+			}
+			fmt.Fprintf(w, `{"topalbums":{"album":[%s]}}`, strings.Join(albums, ","))
+		default:
+			t.Errorf("unexpected Last.fm method %q", query.Get("method"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestLastFMAdapter_GetArtistAlbums(t *testing.T) {
+	server, _ := topAlbumsServer(t, []string{"Believe", "(null)", "Heart of Stone", "", "(null)", "Closer", "Love Hurts", "Cher"})
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	names := func(albums []domain.Album) []string {
+		var names []string
+		for _, album := range albums {
+			names = append(names, album.Attributes.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name          string
+		limit, offset int
+		expected      []string
+	}{
+		{"First page skips entries without a name", 3, 0, []string{"Believe", "Heart of Stone", "Closer"}},
+		{"Offset counts only albums", 2, 2, []string{"Closer", "Love Hurts"}},
+		{"Short last page", 3, 3, []string{"Love Hurts", "Cher"}},
+		{"Offset past the end", 3, 5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albums, err := adapter.GetArtistAlbums(context.Background(), "", "cher", tt.limit, tt.offset)
+			require.NoError(t, err)
+			assert.NotNil(t, albums)
+			assert.Equal(t, tt.expected, names(albums))
+		})
+	}
+}
+
+func TestLastFMAdapter_GetArtistWithAlbums(t *testing.T) {
+	server, requests := topAlbumsServer(t, []string{"Believe", "Heart of Stone", "(null)", "Closer"})
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+
+	// Os álbuns sem tags recebem os gêneros do artist.getInfo, sem consultar o artist.getTopTags
+	result, err := adapter.GetArtistWithAlbums(context.Background(), "", "cher", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Cher", result.Artist.Attributes.Name)
+	assert.Equal(t, []string{"Pop", "Disco", "Music"}, result.Artist.Attributes.GenreNames)
+	require.Len(t, result.Albums, 2)
+	assert.Equal(t, "Heart of Stone", result.Albums[1].Attributes.Name)
+	assert.Equal(t, []string{"Pop", "Disco", "Music"}, result.Albums[1].Attributes.GenreNames)
+
+	// O artista é resolvido uma única vez
+	assert.Equal(t, 1, requests["artist.search"])
+	assert.Equal(t, 1, requests["artist.getInfo"])
+	assert.Equal(t, 1, requests["artist.getTopAlbums"])
+	assert.Zero(t, requests["artist.getTopTags"])
+}
+
+func TestLastFMAdapter_GetAlbumWithTracks(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("method") {
+		case "album.search":
+			w.Write([]byte(albumSearchBody))
+		case "album.getInfo":
+			requests++
+			w.Write([]byte(albumInfoBody))
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestAdapter(t, "test-key", WithBaseURL(server.URL)).GetAlbumWithTracks(context.Background(), "", "cher-believe")
+	require.NoError(t, err)
+	assert.Equal(t, "Believe", result.Album.Attributes.Name)
+	assert.Equal(t, 2, result.Album.Attributes.TrackCount)
+	require.Len(t, result.Tracks, 2)
+	assert.Equal(t, "cher-the-power", result.Tracks[1].ID)
+	assert.Equal(t, 1, requests, "album and tracks come from one album.getInfo")
+}
+
+func TestLastFMAdapter_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected domain.ErrorKind
+	}{
+		{"Invalid parameters become not found", http.StatusOK, `{"error":6,"message":"Album not found"}`, domain.KindNotFound},
+		{"Rate limit exceeded", http.StatusOK, `{"error":29,"message":"Rate Limit Exceeded"}`, domain.KindRateLimited},
+		{"Unexpected status", http.StatusServiceUnavailable, `Service Unavailable`, domain.KindUpstream},
+		{"Invalid JSON", http.StatusOK, `{"album":`, domain.KindUpstream},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("method") == "album.search" {
+					w.Write([]byte(albumSearchBody))
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := newTestAdapter(t, "test-key", WithBaseURL(server.URL)).GetAlbum(context.Background(), "", "cher-believe")
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, domain.KindOf(err))
+		})
+	}
+}
+
+func TestLastFMAdapter_NotFoundInSearch(t *testing.T) {
+	server := fakeLastFM(t, map[string]string{"album.search": albumSearchBody})
+
+	_, err := newTestAdapter(t, "test-key", WithBaseURL(server.URL)).GetAlbum(context.Background(), "", "madonna-believe")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestLastFMAdapter_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	// Timeout do cliente HTTP
+	adapter := newTestAdapter(t, "test-key", WithBaseURL(server.URL), WithTimeout(20*time.Millisecond))
+	_, err := adapter.SearchArtists(context.Background(), "", "cher", 5, 0)
+	require.Error(t, err)
+	assert.Equal(t, domain.KindTimeout, domain.KindOf(err))
+
+	// Prazo do contexto da requisição
+	adapter = newTestAdapter(t, "test-key", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = adapter.SearchArtists(ctx, "", "cher", 5, 0)
+	require.Error(t, err)
+	assert.Equal(t, domain.KindTimeout, domain.KindOf(err))
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "the request is cancelled with the context")
+}
+
+func TestLastFMAdapter_Canceled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := newTestAdapter(t, "test-key", WithBaseURL(server.URL)).SearchSongs(ctx, "", "cher", 5, 0)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Zero(t, requests)
+}
+
+func TestNewLastFMAdapter(t *testing.T) {
+	_, err := NewLastFMAdapter()
+	assert.Error(t, err, "credentials are required")
+
+	_, err = NewLastFMAdapter(WithCredentials("key", "secret"), WithBaseURL("not a url"))
+	assert.Error(t, err)
+
+	adapter := newTestAdapter(t, "key")
+	assert.Equal(t, DefaultBaseURL, adapter.baseURL)
+	assert.Equal(t, DefaultTimeout, adapter.client.Timeout)
+
+	// O cliente informado é copiado, e não alterado pelas demais opções
+	client := &http.Client{Timeout: time.Minute}
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("unused") })
+	adapter = newTestAdapter(t, "key", WithHTTPClient(client), WithTransport(transport))
+	assert.Equal(t, time.Minute, adapter.client.Timeout)
+	assert.NotNil(t, adapter.client.Transport)
+	assert.Nil(t, client.Transport)
+}
+
+func TestLastFMAdapter_RequestErrorHidesAPIKey(t *testing.T) {
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	_, err := newTestAdapter(t, "secret-key", WithTransport(transport)).SearchSongs(context.Background(), "", "cher", 5, 0)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-key")
+}
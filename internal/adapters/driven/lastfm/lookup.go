@@ -0,0 +1,468 @@
+package lastfm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+)
+
+// Número de resultados consultados ao resolver um ID do catálogo via busca
+const lookupSearchLimit = 30
+
+// Número máximo de páginas do artist.getTopAlbums consultadas para uma página de álbuns
+const maxTopAlbumsPages = 5
+
+// GetSong resolve o ID gerado por SearchSongs de volta para uma música.
+// Como o Last.fm não possui IDs estáveis para faixas, o ID é convertido em
+// termo de busca e o resultado cujo ID gerado coincide é detalhado via track.getInfo.
+// https://www.last.fm/api/show/track.getInfo
+func (a *LastFMAdapter) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	artistName, trackName, err := a.findTrack(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("method", "track.getInfo")
+	params.Set("artist", artistName)
+	params.Set("track", trackName)
+	setLang(params, language)
+
+	var result struct {
+		Track struct {
+			Name     string `json:"name"`
+			Duration string `json:"duration"`
+			Artist   struct {
+				Name string `json:"name"`
+			} `json:"artist"`
+			Album struct {
+				Title string  `json:"title"`
+				Image []image `json:"image"`
+				Attr  struct {
+					Position string `json:"position"`
+				} `json:"@attr"`
+			} `json:"album"`
+			TopTags tags `json:"toptags"`
+		} `json:"track"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return nil, notFoundOr(err)
+	}
+
+	duration, _ := strconv.Atoi(result.Track.Duration)
+	trackNumber, _ := strconv.Atoi(result.Track.Album.Attr.Position)
+	genres, err := a.newGenreResolver().orArtist(ctx, genresOf(result.Track.TopTags.Tag), result.Track.Artist.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Song{
+		ID:   id,
+		Type: "songs",
+		Attributes: domain.SongAttributes{
+			Name:             result.Track.Name,
+			ArtistName:       result.Track.Artist.Name,
+			AlbumName:        result.Track.Album.Title,
+			DurationInMillis: duration,
+			TrackNumber:      trackNumber,
+			GenreNames:       genres,
+			Artwork: domain.Artwork{
+				URL: largeImageURL(result.Track.Album.Image),
+			},
+			PlayParams: domain.PlayParams{
+				ID:   id,
+				Kind: "song",
+			},
+		},
+	}, nil
+}
+
+// GetAlbum resolve o ID gerado por SearchAlbums e detalha o álbum via album.getInfo
+// https://www.last.fm/api/show/album.getInfo
+func (a *LastFMAdapter) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	result, err := a.GetAlbumWithTracks(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Album, nil
+}
+
+// GetArtist resolve o ID gerado por SearchArtists e detalha o artista via artist.getInfo
+// https://www.last.fm/api/show/artist.getInfo
+func (a *LastFMAdapter) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	artistName, err := a.findArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	artist, _, err := a.artistInfo(ctx, language, id, artistName)
+	return artist, err
+}
+
+// GetAlbumTracks retorna a tracklist do álbum obtida via album.getInfo
+func (a *LastFMAdapter) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	result, err := a.GetAlbumWithTracks(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	return result.Tracks, nil
+}
+
+// GetAlbumWithTracks detalha o álbum e a sua tracklist a partir do mesmo album.getInfo
+func (a *LastFMAdapter) GetAlbumWithTracks(ctx context.Context, language, id string) (*driven.AlbumWithTracks, error) {
+	info, err := a.albumInfo(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	genres, err := a.newGenreResolver().orArtist(ctx, genresOf(info.Tags.Tag), info.Artist)
+	if err != nil {
+		return nil, err
+	}
+
+	artworkURL := largeImageURL(info.Image)
+	album := domain.Album{
+		ID:   id,
+		Type: "albums",
+		Attributes: domain.AlbumAttributes{
+			Name:       info.Name,
+			ArtistName: info.Artist,
+			Artwork: domain.Artwork{
+				URL: artworkURL,
+			},
+			PlayParams: domain.PlayParams{
+				ID:   id,
+				Kind: "album",
+			},
+			URL:        info.URL,
+			GenreNames: genres,
+			TrackCount: len(info.Tracks.Track),
+			EditorialNotes: domain.EditorialNotes{
+				Standard: stripReadMore(info.Wiki.Content),
+				Short:    stripReadMore(info.Wiki.Summary),
+			},
+			IsSingle:   len(info.Tracks.Track) == 1,
+			IsComplete: true,
+		},
+	}
+
+	songs := make([]domain.Song, 0, len(info.Tracks.Track))
+	for i, track := range info.Tracks.Track {
+		artistName := track.Artist.Name
+		if artistName == "" {
+			artistName = info.Artist
+		}
+		trackNumber := track.Attr.Rank
+		if trackNumber == 0 {
+			trackNumber = i + 1
+		}
+
+		songID := slugify(artistName + " " + track.Name)
+		songs = append(songs, domain.Song{
+			ID:   songID,
+			Type: "songs",
+			Attributes: domain.SongAttributes{
+				Name:             track.Name,
+				ArtistName:       artistName,
+				AlbumName:        info.Name,
+				TrackNumber:      trackNumber,
+				DiscNumber:       1,
+				DurationInMillis: track.Duration * 1000, // album.getInfo retorna a duração em segundos
+				GenreNames:       append([]string{}, genres...),
+				Artwork: domain.Artwork{
+					URL: artworkURL,
+				},
+				PlayParams: domain.PlayParams{
+					ID:   songID,
+					Kind: "song",
+				},
+			},
+		})
+	}
+	return &driven.AlbumWithTracks{Album: album, Tracks: songs}, nil
+}
+
+// GetArtistWithAlbums detalha o artista e os seus álbuns mais populares resolvendo o
+// ID uma única vez. Os álbuns sem tags de gênero recebem os gêneros do artist.getInfo.
+func (a *LastFMAdapter) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	artistName, err := a.findArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	artist, genres, err := a.artistInfo(ctx, language, id, artistName)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := a.newGenreResolver().withArtist(artist.Attributes.Name, genres)
+	albums, err := a.topAlbums(ctx, resolver, artistName, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &driven.ArtistWithAlbums{Artist: *artist, Albums: albums}, nil
+}
+
+// artistInfo consulta o artist.getInfo do artista com o nome informado e retorna
+// também os gêneros das suas tags
+func (a *LastFMAdapter) artistInfo(ctx context.Context, language, id, artistName string) (*domain.Artist, []domain.Genre, error) {
+	params := url.Values{}
+	params.Set("method", "artist.getInfo")
+	params.Set("artist", artistName)
+	setLang(params, language)
+
+	var result struct {
+		Artist struct {
+			Name  string  `json:"name"`
+			URL   string  `json:"url"`
+			Image []image `json:"image"`
+			Tags  tags    `json:"tags"`
+		} `json:"artist"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return nil, nil, notFoundOr(err)
+	}
+
+	genres := genresOf(result.Artist.Tags.Tag)
+	return &domain.Artist{
+		ID:   id,
+		Type: "artists",
+		Attributes: domain.ArtistAttributes{
+			Name:       result.Artist.Name,
+			GenreNames: domain.GenreNames(genres),
+			URL:        result.Artist.URL,
+			Artwork: domain.Artwork{
+				URL: largeImageURL(result.Artist.Image),
+			},
+		},
+	}, genres, nil
+}
+
+// GetArtistAlbums retorna os álbuns mais populares do artista via artist.getTopAlbums
+// https://www.last.fm/api/show/artist.getTopAlbums
+func (a *LastFMAdapter) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	artistName, err := a.findArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return a.topAlbums(ctx, a.newGenreResolver(), artistName, limit, offset)
+}
+
+// topAlbums consulta o artist.getTopAlbums do artista. O Last.fm pagina por página e
+// inclui entradas sem nome para faixas avulsas, então as páginas são consultadas até
+// haver offset+limit álbuns com nome, e só então offset e limit são aplicados.
+func (a *LastFMAdapter) topAlbums(ctx context.Context, genres *genreResolver, artistName string, limit, offset int) ([]domain.Album, error) {
+	pageSize := offset + limit
+	albums := []domain.Album{}
+	for page := 1; page <= maxTopAlbumsPages && len(albums) < pageSize; page++ {
+		params := url.Values{}
+		params.Set("method", "artist.getTopAlbums")
+		params.Set("artist", artistName)
+		params.Set("limit", strconv.Itoa(pageSize))
+		params.Set("page", strconv.Itoa(page))
+
+		var result struct {
+			TopAlbums struct {
+				Album flexList[struct {
+					Name   string `json:"name"`
+					URL    string `json:"url"`
+					Artist struct {
+						Name string `json:"name"`
+					} `json:"artist"`
+					Image []image `json:"image"`
+				}] `json:"album"`
+			} `json:"topalbums"`
+		}
+		if err := a.get(ctx, params, &result); err != nil {
+			return nil, notFoundOr(err)
+		}
+
+		for _, album := range result.TopAlbums.Album {
+			if album.Name == "" || album.Name == "(null)" {
+				continue
+			}
+
+			albumID := slugify(album.Artist.Name + " " + album.Name)
+			albums = append(albums, domain.Album{
+				ID:   albumID,
+				Type: "albums",
+				Attributes: domain.AlbumAttributes{
+					Name:       album.Name,
+					ArtistName: album.Artist.Name,
+					URL:        album.URL,
+					Artwork: domain.Artwork{
+						URL: largeImageURL(album.Image),
+					},
+					PlayParams: domain.PlayParams{
+						ID:   albumID,
+						Kind: "album",
+					},
+					IsComplete: true,
+				},
+			})
+		}
+
+		// Uma página incompleta é a última
+		if len(result.TopAlbums.Album) < pageSize {
+			break
+		}
+	}
+
+	if offset >= len(albums) {
+		return []domain.Album{}, nil
+	}
+	albums = albums[offset:min(len(albums), pageSize)]
+
+	// Os álbuns sem tags de gênero compartilham os gêneros do artista
+	if err := genres.albums(ctx, albums); err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+// albumInfo representa a resposta do album.getInfo
+type albumInfo struct {
+	Name   string  `json:"name"`
+	Artist string  `json:"artist"`
+	URL    string  `json:"url"`
+	Image  []image `json:"image"`
+	Tracks struct {
+		Track flexList[albumTrack] `json:"track"`
+	} `json:"tracks"`
+	Tags tags `json:"tags"`
+	Wiki struct {
+		Summary string `json:"summary"`
+		Content string `json:"content"`
+	} `json:"wiki"`
+}
+
+// albumTrack representa uma faixa na tracklist do album.getInfo
+type albumTrack struct {
+	Name     string `json:"name"`
+	Duration int    `json:"duration"`
+	Attr     struct {
+		Rank int `json:"rank"`
+	} `json:"@attr"`
+	Artist struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+}
+
+// albumInfo resolve o ID de um álbum e consulta o album.getInfo
+func (a *LastFMAdapter) albumInfo(ctx context.Context, language, id string) (*albumInfo, error) {
+	artistName, albumName, err := a.findAlbum(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("method", "album.getInfo")
+	params.Set("artist", artistName)
+	params.Set("album", albumName)
+	setLang(params, language)
+
+	var result struct {
+		Album albumInfo `json:"album"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return nil, notFoundOr(err)
+	}
+	return &result.Album, nil
+}
+
+// findTrack procura no track.search a faixa cujo ID gerado corresponde a id
+func (a *LastFMAdapter) findTrack(ctx context.Context, id string) (artistName, trackName string, err error) {
+	params := url.Values{}
+	params.Set("method", "track.search")
+	params.Set("track", unslugify(id))
+	params.Set("limit", strconv.Itoa(lookupSearchLimit))
+
+	var result struct {
+		Results struct {
+			TrackMatches struct {
+				Track []struct {
+					Name   string `json:"name"`
+					Artist string `json:"artist"`
+				} `json:"track"`
+			} `json:"trackmatches"`
+		} `json:"results"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return "", "", err
+	}
+
+	for _, track := range result.Results.TrackMatches.Track {
+		if slugify(track.Artist+" "+track.Name) == id {
+			return track.Artist, track.Name, nil
+		}
+	}
+	return "", "", domain.NotFound(fmt.Sprintf("song %s not found", id))
+}
+
+// findAlbum procura no album.search o álbum cujo ID gerado corresponde a id
+func (a *LastFMAdapter) findAlbum(ctx context.Context, id string) (artistName, albumName string, err error) {
+	params := url.Values{}
+	params.Set("method", "album.search")
+	params.Set("album", unslugify(id))
+	params.Set("limit", strconv.Itoa(lookupSearchLimit))
+
+	var result struct {
+		Results struct {
+			AlbumMatches struct {
+				Album []struct {
+					Name   string `json:"name"`
+					Artist string `json:"artist"`
+				} `json:"album"`
+			} `json:"albummatches"`
+		} `json:"results"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return "", "", err
+	}
+
+	for _, album := range result.Results.AlbumMatches.Album {
+		if slugify(album.Artist+" "+album.Name) == id {
+			return album.Artist, album.Name, nil
+		}
+	}
+	return "", "", domain.NotFound(fmt.Sprintf("album %s not found", id))
+}
+
+// findArtist procura no artist.search o artista cujo ID gerado corresponde a id
+func (a *LastFMAdapter) findArtist(ctx context.Context, id string) (string, error) {
+	params := url.Values{}
+	params.Set("method", "artist.search")
+	params.Set("artist", unslugify(id))
+	params.Set("limit", strconv.Itoa(lookupSearchLimit))
+
+	var result struct {
+		Results struct {
+			ArtistMatches struct {
+				Artist []struct {
+					Name string `json:"name"`
+				} `json:"artist"`
+			} `json:"artistmatches"`
+		} `json:"results"`
+	}
+	if err := a.get(ctx, params, &result); err != nil {
+		return "", err
+	}
+
+	for _, artist := range result.Results.ArtistMatches.Artist {
+		if slugify(artist.Name) == id {
+			return artist.Name, nil
+		}
+	}
+	return "", domain.NotFound(fmt.Sprintf("artist %s not found", id))
+}
+
+// notFoundOr converte o erro de parâmetro inválido do Last.fm em um erro de recurso inexistente
+func notFoundOr(err error) error {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Code == lastfmErrInvalidParameters {
+		return domain.NotFound(apiErr.Message)
+	}
+	return err
+}
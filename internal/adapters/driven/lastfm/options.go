@@ -0,0 +1,92 @@
+package lastfm
+
+import (
+	"net/http"
+	"time"
+)
+
+// Valores padrão do adaptador
+const (
+	// DefaultBaseURL é o endpoint da API pública do Last.fm
+	DefaultBaseURL = "https://ws.audioscrobbler.com/2.0/"
+	// DefaultTimeout é o tempo máximo de cada requisição ao Last.fm
+	DefaultTimeout = 10 * time.Second
+)
+
+// Option configura o LastFMAdapter
+type Option func(*options)
+
+type options struct {
+	apiKey    string
+	apiSecret string
+	baseURL   string
+	client    *http.Client
+	transport http.RoundTripper
+	timeout   *time.Duration
+
+	genreLookups bool
+}
+
+// WithCredentials define a chave e o segredo da API do Last.fm
+func WithCredentials(apiKey, apiSecret string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+		o.apiSecret = apiSecret
+	}
+}
+
+// WithBaseURL aponta o adaptador para outro endpoint, como um fake do Last.fm em testes
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient usa o cliente HTTP informado nas requisições.
+// WithTransport e WithTimeout, se informados, sobrescrevem os valores do cliente.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithTransport define o http.RoundTripper usado nas requisições, como um Cassette
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout define o tempo máximo de cada requisição; zero desativa o limite
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = &timeout
+	}
+}
+
+// WithGenreLookups define se as tags de cada resultado de buscas, rankings e álbuns
+// de artistas, cujas respostas não trazem tags, são consultadas via getTopTags (o
+// padrão). Cada resultado custa uma requisição a mais ao Last.fm; desabilitada, esses
+// resultados recebem apenas o gênero raiz. Os detalhes de músicas, álbuns e artistas
+// usam sempre as tags do getInfo.
+func WithGenreLookups(enabled bool) Option {
+	return func(o *options) {
+		o.genreLookups = enabled
+	}
+}
+
+// httpClient monta o cliente HTTP a partir das opções, sem alterar o cliente informado.
+// Sem WithHTTPClient nem WithTimeout, o cliente usa DefaultTimeout.
+func (o options) httpClient() *http.Client {
+	client := &http.Client{Timeout: DefaultTimeout}
+	if o.client != nil {
+		*client = *o.client
+	}
+	if o.transport != nil {
+		client.Transport = o.transport
+	}
+	if o.timeout != nil {
+		client.Timeout = *o.timeout
+	}
+	return client
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// LibraryRepository implementa a interface LibraryRepository guardando as
+// bibliotecas em memória; elas se perdem quando o servidor é reiniciado.
+type LibraryRepository struct {
+	mu        sync.RWMutex
+	libraries map[string]*library
+}
+
+// library guarda os itens da biblioteca de um usuário
+type library struct {
+	songs   collection[domain.LibrarySong]
+	albums  collection[domain.LibraryAlbum]
+	artists collection[domain.LibraryArtist]
+}
+
+// NewLibraryRepository cria um repositório de bibliotecas vazio
+func NewLibraryRepository() *LibraryRepository {
+	return &LibraryRepository{
+		libraries: make(map[string]*library),
+	}
+}
+
+func (r *LibraryRepository) ListSongs(ctx context.Context, userID string) ([]domain.LibrarySong, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(userID).songs.list(), nil
+}
+
+func (r *LibraryRepository) GetSong(ctx context.Context, userID, id string) (*domain.LibrarySong, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(userID).songs.get(id, "song")
+}
+
+func (r *LibraryRepository) AddSong(ctx context.Context, userID string, song domain.LibrarySong) (*domain.LibrarySong, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.library(userID).songs.add(song.ID, song.Song.ID, song), nil
+}
+
+func (r *LibraryRepository) ListAlbums(ctx context.Context, userID string) ([]domain.LibraryAlbum, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(userID).albums.list(), nil
+}
+
+func (r *LibraryRepository) GetAlbum(ctx context.Context, userID, id string) (*domain.LibraryAlbum, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(userID).albums.get(id, "album")
+}
+
+func (r *LibraryRepository) AddAlbum(ctx context.Context, userID string, album domain.LibraryAlbum) (*domain.LibraryAlbum, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.library(userID).albums.add(album.ID, album.Album.ID, album), nil
+}
+
+func (r *LibraryRepository) ListArtists(ctx context.Context, userID string) ([]domain.LibraryArtist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(userID).artists.list(), nil
+}
+
+func (r *LibraryRepository) GetArtist(ctx context.Context, userID, id string) (*domain.LibraryArtist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.find(userID).artists.get(id, "artist")
+}
+
+func (r *LibraryRepository) AddArtist(ctx context.Context, userID string, artist domain.LibraryArtist) (*domain.LibraryArtist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.library(userID).artists.add(artist.ID, strings.ToLower(artist.Name), artist), nil
+}
+
+// library retorna a biblioteca do usuário, criando-a se necessário. Deve ser
+// chamado com r.mu travado para escrita.
+func (r *LibraryRepository) library(userID string) *library {
+	lib, ok := r.libraries[userID]
+	if !ok {
+		lib = &library{}
+		r.libraries[userID] = lib
+	}
+	return lib
+}
+
+// find retorna a biblioteca do usuário ou uma biblioteca vazia, sem guardá-la.
+// Deve ser chamado com r.mu travado.
+func (r *LibraryRepository) find(userID string) *library {
+	if lib, ok := r.libraries[userID]; ok {
+		return lib
+	}
+	return &library{}
+}
+
+// collection guarda itens da biblioteca na ordem em que foram adicionados,
+// indexados pelo ID na biblioteca e por uma chave de unicidade, como o ID do
+// recurso do catálogo
+type collection[T any] struct {
+	items []T
+	byID  map[string]int
+	byKey map[string]int
+}
+
+func (c *collection[T]) list() []T {
+	return append(make([]T, 0, len(c.items)), c.items...)
+}
+
+func (c *collection[T]) get(id, kind string) (*T, error) {
+	i, ok := c.byID[id]
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("library %s %s not found", kind, id))
+	}
+	item := c.items[i]
+	return &item, nil
+}
+
+// add guarda o item se ainda não houver um item com a mesma chave e retorna o item guardado
+func (c *collection[T]) add(id, key string, item T) *T {
+	if i, ok := c.byKey[key]; ok {
+		existing := c.items[i]
+		return &existing
+	}
+	if c.byID == nil {
+		c.byID = make(map[string]int)
+		c.byKey = make(map[string]int)
+	}
+	c.byID[id] = len(c.items)
+	c.byKey[key] = len(c.items)
+	c.items = append(c.items, item)
+	return &item
+}
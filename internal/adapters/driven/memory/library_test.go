@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryRepository(t *testing.T) {
+	repo := NewLibraryRepository()
+	ctx := context.Background()
+
+	song, err := repo.AddSong(ctx, "alice", domain.LibrarySong{ID: "i.1", Song: domain.Song{ID: "song-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "i.1", song.ID)
+
+	// Uma música do catálogo já adicionada mantém o seu ID na biblioteca
+	song, err = repo.AddSong(ctx, "alice", domain.LibrarySong{ID: "i.2", Song: domain.Song{ID: "song-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "i.1", song.ID)
+
+	_, err = repo.AddSong(ctx, "alice", domain.LibrarySong{ID: "i.3", Song: domain.Song{ID: "song-2"}})
+	require.NoError(t, err)
+	songs, err := repo.ListSongs(ctx, "alice")
+	require.NoError(t, err)
+	if assert.Len(t, songs, 2) {
+		assert.Equal(t, "i.1", songs[0].ID)
+		assert.Equal(t, "i.3", songs[1].ID)
+	}
+
+	song, err = repo.GetSong(ctx, "alice", "i.3")
+	require.NoError(t, err)
+	assert.Equal(t, "song-2", song.Song.ID)
+	_, err = repo.GetSong(ctx, "alice", "i.2")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	_, err = repo.GetSong(ctx, "bob", "i.1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "libraries belong to their user")
+
+	_, err = repo.AddAlbum(ctx, "alice", domain.LibraryAlbum{ID: "l.1", Album: domain.Album{ID: "album-1"}})
+	require.NoError(t, err)
+	album, err := repo.GetAlbum(ctx, "alice", "l.1")
+	require.NoError(t, err)
+	assert.Equal(t, "album-1", album.Album.ID)
+
+	// Artistas são únicos pelo nome, sem diferenciar maiúsculas
+	_, err = repo.AddArtist(ctx, "alice", domain.LibraryArtist{ID: "r.1", Name: "Queen"})
+	require.NoError(t, err)
+	artist, err := repo.AddArtist(ctx, "alice", domain.LibraryArtist{ID: "r.2", Name: "QUEEN"})
+	require.NoError(t, err)
+	assert.Equal(t, "r.1", artist.ID)
+	artists, err := repo.ListArtists(ctx, "alice")
+	require.NoError(t, err)
+	assert.Len(t, artists, 1)
+
+	albums, err := repo.ListAlbums(ctx, "bob")
+	require.NoError(t, err)
+	assert.NotNil(t, albums)
+	assert.Empty(t, albums)
+}
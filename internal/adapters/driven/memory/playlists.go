@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// PlaylistRepository implementa a interface PlaylistRepository guardando as
+// playlists em memória; elas se perdem quando o servidor é reiniciado.
+type PlaylistRepository struct {
+	mu sync.RWMutex
+	// Playlists por usuário, na ordem em que foram criadas
+	playlists map[string][]*domain.Playlist
+}
+
+// NewPlaylistRepository cria um repositório de playlists vazio
+func NewPlaylistRepository() *PlaylistRepository {
+	return &PlaylistRepository{
+		playlists: make(map[string][]*domain.Playlist),
+	}
+}
+
+func (r *PlaylistRepository) ListPlaylists(ctx context.Context, userID string) ([]domain.Playlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	playlists := make([]domain.Playlist, 0, len(r.playlists[userID]))
+	for _, playlist := range r.playlists[userID] {
+		playlists = append(playlists, clonePlaylist(playlist))
+	}
+	return playlists, nil
+}
+
+func (r *PlaylistRepository) GetPlaylist(ctx context.Context, userID, id string) (*domain.Playlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	playlist, err := r.find(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	clone := clonePlaylist(playlist)
+	return &clone, nil
+}
+
+func (r *PlaylistRepository) CreatePlaylist(ctx context.Context, userID string, playlist domain.Playlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.find(userID, playlist.ID); err == nil {
+		return fmt.Errorf("playlist %s already exists", playlist.ID)
+	}
+	clone := clonePlaylist(&playlist)
+	r.playlists[userID] = append(r.playlists[userID], &clone)
+	return nil
+}
+
+func (r *PlaylistRepository) UpdatePlaylist(ctx context.Context, userID, id string, update func(*domain.Playlist) error) (*domain.Playlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	playlist, err := r.find(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	updated := clonePlaylist(playlist)
+	if err := update(&updated); err != nil {
+		return nil, err
+	}
+	*playlist = clonePlaylist(&updated)
+	return &updated, nil
+}
+
+// find retorna a playlist guardada. Deve ser chamado com r.mu travado.
+func (r *PlaylistRepository) find(userID, id string) (*domain.Playlist, error) {
+	for _, playlist := range r.playlists[userID] {
+		if playlist.ID == id {
+			return playlist, nil
+		}
+	}
+	return nil, domain.NotFound(fmt.Sprintf("playlist %s not found", id))
+}
+
+// clonePlaylist copia a playlist, incluindo as faixas, para que as alterações
+// feitas pelos chamadores não afetem a playlist guardada
+func clonePlaylist(playlist *domain.Playlist) domain.Playlist {
+	clone := *playlist
+	clone.Tracks = slices.Clone(playlist.Tracks)
+	return clone
+}
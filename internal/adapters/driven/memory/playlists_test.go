@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistRepository(t *testing.T) {
+	repo := NewPlaylistRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.1", Name: "First"}))
+	require.NoError(t, repo.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.2", Name: "Second"}))
+	require.NoError(t, repo.CreatePlaylist(ctx, "bob", domain.Playlist{ID: "p.3", Name: "Bob's"}))
+	assert.Error(t, repo.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.1"}), "duplicated ID")
+
+	playlists, err := repo.ListPlaylists(ctx, "alice")
+	require.NoError(t, err)
+	if assert.Len(t, playlists, 2) {
+		assert.Equal(t, "p.1", playlists[0].ID)
+		assert.Equal(t, "p.2", playlists[1].ID)
+	}
+
+	playlists, err = repo.ListPlaylists(ctx, "carol")
+	require.NoError(t, err)
+	assert.Empty(t, playlists)
+
+	_, err = repo.GetPlaylist(ctx, "bob", "p.1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "playlists belong to their user")
+
+	updated, err := repo.UpdatePlaylist(ctx, "alice", "p.1", func(p *domain.Playlist) error {
+		return p.AddTrack(domain.Track{ID: "song-1"})
+	})
+	require.NoError(t, err)
+	assert.Len(t, updated.Tracks, 1)
+
+	// Alterar a cópia retornada não afeta a playlist guardada
+	updated.Tracks[0].ID = "changed"
+	playlist, err := repo.GetPlaylist(ctx, "alice", "p.1")
+	require.NoError(t, err)
+	assert.Equal(t, "song-1", playlist.Tracks[0].ID)
+
+	// Um erro em update descarta as alterações
+	_, err = repo.UpdatePlaylist(ctx, "alice", "p.1", func(p *domain.Playlist) error {
+		p.Name = "Renamed"
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	playlist, err = repo.GetPlaylist(ctx, "alice", "p.1")
+	require.NoError(t, err)
+	assert.Equal(t, "First", playlist.Name)
+
+	_, err = repo.UpdatePlaylist(ctx, "alice", "unknown", func(p *domain.Playlist) error { return nil })
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// DefaultMaxPlayEvents é o número padrão de eventos guardados por usuário
+const DefaultMaxPlayEvents = 1000
+
+// PlayHistoryRepository implementa a interface PlayHistoryRepository guardando
+// em memória os eventos mais recentes de cada usuário
+type PlayHistoryRepository struct {
+	maxEvents int
+
+	mu sync.RWMutex
+	// Eventos por usuário, do mais recente para o mais antigo
+	plays map[string][]domain.PlayEvent
+}
+
+// NewPlayHistoryRepository cria um histórico vazio que guarda até maxEvents
+// eventos por usuário, descartando os mais antigos; zero usa DefaultMaxPlayEvents
+func NewPlayHistoryRepository(maxEvents int) *PlayHistoryRepository {
+	if maxEvents <= 0 {
+		maxEvents = DefaultMaxPlayEvents
+	}
+	return &PlayHistoryRepository{
+		maxEvents: maxEvents,
+		plays:     make(map[string][]domain.PlayEvent),
+	}
+}
+
+func (r *PlayHistoryRepository) AddPlays(ctx context.Context, userID string, plays []domain.PlayEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history := r.plays[userID]
+	for _, play := range plays {
+		// Inserir mantendo a ordem; entre eventos no mesmo instante, o último
+		// recebido é considerado o mais recente
+		i, _ := slices.BinarySearchFunc(history, play, func(e, target domain.PlayEvent) int {
+			if e.PlayedAt.After(target.PlayedAt) {
+				return -1
+			}
+			return 1
+		})
+		history = slices.Insert(history, i, play)
+	}
+	if len(history) > r.maxEvents {
+		history = history[:r.maxEvents]
+	}
+	r.plays[userID] = history
+	return nil
+}
+
+func (r *PlayHistoryRepository) ListPlays(ctx context.Context, userID string) ([]domain.PlayEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append(make([]domain.PlayEvent, 0, len(r.plays[userID])), r.plays[userID]...), nil
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayHistoryRepository(t *testing.T) {
+	repo := NewPlayHistoryRepository(4)
+	ctx := context.Background()
+	at := func(minutes int) time.Time { return time.Unix(1700000000, 0).Add(time.Duration(minutes) * time.Minute) }
+
+	require.NoError(t, repo.AddPlays(ctx, "alice", []domain.PlayEvent{
+		{SongID: "b", PlayedAt: at(2)},
+		{SongID: "a", PlayedAt: at(1)},
+		{SongID: "c", PlayedAt: at(3)},
+	}))
+	// Eventos fora de ordem e no mesmo instante de outro evento
+	require.NoError(t, repo.AddPlays(ctx, "alice", []domain.PlayEvent{
+		{SongID: "b2", PlayedAt: at(2)},
+	}))
+
+	plays, err := repo.ListPlays(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b2", "b", "a"}, songIDs(plays))
+
+	// Acima do limite, os eventos mais antigos são descartados
+	require.NoError(t, repo.AddPlays(ctx, "alice", []domain.PlayEvent{{SongID: "d", PlayedAt: at(4)}}))
+	plays, err = repo.ListPlays(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d", "c", "b2", "b"}, songIDs(plays))
+
+	plays, err = repo.ListPlays(ctx, "bob")
+	require.NoError(t, err)
+	assert.Empty(t, plays)
+}
+
+func songIDs(plays []domain.PlayEvent) []string {
+	ids := make([]string, len(plays))
+	for i, play := range plays {
+		ids[i] = play.SongID
+	}
+	return ids
+}
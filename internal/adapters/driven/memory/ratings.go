@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// ratingKey identifica a avaliação de um recurso
+type ratingKey struct {
+	resourceType, id string
+}
+
+// RatingRepository implementa a interface RatingRepository guardando as
+// avaliações em memória; elas se perdem quando o servidor é reiniciado.
+type RatingRepository struct {
+	mu      sync.RWMutex
+	ratings map[string]map[ratingKey]domain.Rating
+}
+
+// NewRatingRepository cria um repositório de avaliações vazio
+func NewRatingRepository() *RatingRepository {
+	return &RatingRepository{
+		ratings: make(map[string]map[ratingKey]domain.Rating),
+	}
+}
+
+func (r *RatingRepository) GetRating(ctx context.Context, userID, resourceType, id string) (*domain.Rating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rating, found := r.ratings[userID][ratingKey{resourceType, id}]
+	if !found {
+		return nil, domain.NotFound(fmt.Sprintf("rating for %s %s not found", resourceType, id))
+	}
+	return &rating, nil
+}
+
+func (r *RatingRepository) PutRating(ctx context.Context, userID string, rating domain.Rating) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ratings[userID] == nil {
+		r.ratings[userID] = make(map[ratingKey]domain.Rating)
+	}
+	r.ratings[userID][ratingKey{rating.ResourceType, rating.ResourceID}] = rating
+	return nil
+}
+
+func (r *RatingRepository) DeleteRating(ctx context.Context, userID, resourceType, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := ratingKey{resourceType, id}
+	if _, found := r.ratings[userID][key]; !found {
+		return domain.NotFound(fmt.Sprintf("rating for %s %s not found", resourceType, id))
+	}
+	delete(r.ratings[userID], key)
+	return nil
+}
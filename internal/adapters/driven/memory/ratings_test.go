@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingRepository(t *testing.T) {
+	repo := NewRatingRepository()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+
+	require.NoError(t, repo.PutRating(ctx, "alice", domain.Rating{ResourceType: "songs", ResourceID: "s1", Value: domain.RatingLove, UpdatedAt: now}))
+	require.NoError(t, repo.PutRating(ctx, "alice", domain.Rating{ResourceType: "albums", ResourceID: "a1", Value: domain.RatingDislike, UpdatedAt: now.Add(time.Minute)}))
+	// Uma nova avaliação substitui a anterior do mesmo recurso
+	require.NoError(t, repo.PutRating(ctx, "alice", domain.Rating{ResourceType: "songs", ResourceID: "s1", Value: domain.RatingDislike, UpdatedAt: now.Add(2 * time.Minute)}))
+
+	rating, err := repo.GetRating(ctx, "alice", "songs", "s1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingDislike, rating.Value)
+
+	rating, err = repo.GetRating(ctx, "alice", "albums", "a1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingDislike, rating.Value)
+
+	_, err = repo.GetRating(ctx, "bob", "songs", "s1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "ratings belong to their user")
+
+	require.NoError(t, repo.DeleteRating(ctx, "alice", "songs", "s1"))
+	_, err = repo.GetRating(ctx, "alice", "songs", "s1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.True(t, errors.Is(repo.DeleteRating(ctx, "alice", "songs", "s1"), domain.ErrNotFound))
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// AdminHandler lida com as rotas de administração do simulador, que não fazem
+// parte da Apple Music API e não exigem developer token. As rotas são servidas
+// por AdminRouter, fora do roteador da API.
+type AdminHandler struct {
+	userService    driving.UserService
+	historyService driving.HistoryService
+}
+
+// NewAdminHandler cria uma nova instância do handler de administração
+func NewAdminHandler(userService driving.UserService, historyService driving.HistoryService) *AdminHandler {
+	return &AdminHandler{
+		userService:    userService,
+		historyService: historyService,
+	}
+}
+
+// issueUserTokenRequest é o corpo opcional da emissão de um Music-User-Token
+type issueUserTokenRequest struct {
+	UserID     string `json:"userId"`
+	Storefront string `json:"storefront"`
+	ExpiresIn  string `json:"expiresIn"` // Duração no formato do Go, como "1h"
+}
+
+// userTokenResponse representa um Music-User-Token emitido
+type userTokenResponse struct {
+	Token      string    `json:"token"`
+	UserID     string    `json:"userId"`
+	Storefront string    `json:"storefront"`
+	IssuedAt   time.Time `json:"issuedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// IssueUserToken processa a emissão de um Music-User-Token
+func (h *AdminHandler) IssueUserToken(w http.ResponseWriter, r *http.Request) {
+	var req issueUserTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, domain.InvalidParameter("", "request body must be a JSON object"))
+		return
+	}
+
+	params := driving.IssueUserTokenParameters{UserID: req.UserID, Storefront: req.Storefront}
+	if req.ExpiresIn != "" {
+		ttl, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil {
+			writeError(w, domain.InvalidParameter("expiresIn", "expiresIn must be a duration such as 1h"))
+			return
+		}
+		params.TTL = ttl
+	}
+
+	token, err := h.userService.IssueToken(r.Context(), params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, userTokenResponse{
+		Token:      token.Token,
+		UserID:     token.User.ID,
+		Storefront: token.User.Storefront,
+		IssuedAt:   token.IssuedAt,
+		ExpiresAt:  token.ExpiresAt,
+	})
+}
+
+// RevokeUserToken processa a revogação de um Music-User-Token
+func (h *AdminHandler) RevokeUserToken(w http.ResponseWriter, r *http.Request) {
+	if err := h.userService.RevokeToken(r.Context(), pathParam(r, "token")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// playEventsRequest é o corpo do registro de eventos de reprodução
+type playEventsRequest struct {
+	Data []struct {
+		SongID    string    `json:"songId"`
+		PlayedAt  time.Time `json:"playedAt"` // Opcional; ausente usa o momento atual
+		Container *struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"container"`
+	} `json:"data"`
+}
+
+// RecordPlays processa o registro de eventos de reprodução de um usuário, que
+// alimentam as rotas de histórico
+func (h *AdminHandler) RecordPlays(w http.ResponseWriter, r *http.Request) {
+	var req playEventsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	plays := make([]domain.PlayEvent, len(req.Data))
+	for i, event := range req.Data {
+		plays[i] = domain.PlayEvent{SongID: event.SongID, PlayedAt: event.PlayedAt}
+		if c := event.Container; c != nil {
+			plays[i].Container = &domain.PlayContainer{Type: c.Type, ID: c.ID, Name: c.Name}
+		}
+	}
+
+	if err := h.historyService.RecordPlays(r.Context(), pathParam(r, "userId"), plays); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
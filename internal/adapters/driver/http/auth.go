@@ -0,0 +1,218 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// MaxDeveloperTokenLifetime é a validade máxima de um developer token aceita
+// pela Apple: seis meses entre iat e exp
+const MaxDeveloperTokenLifetime = 15777000 * time.Second
+
+// DeveloperTokenAuth exige um developer token válido no header Authorization,
+// como a Apple Music API. O token é um JWT ES256 assinado com uma chave do
+// MusicKit; o kid do header identifica a chave pública usada na verificação.
+type DeveloperTokenAuth struct {
+	keys map[string]*ecdsa.PublicKey
+	now  func() time.Time
+}
+
+// NewDeveloperTokenAuth cria o autenticador com as chaves públicas aceitas, por kid
+func NewDeveloperTokenAuth(keys map[string]*ecdsa.PublicKey) *DeveloperTokenAuth {
+	return &DeveloperTokenAuth{keys: keys, now: time.Now}
+}
+
+// tokenHeader é o cabeçalho de um developer token
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// tokenClaims são as claims de um developer token. O iss é o Team ID da conta
+// de desenvolvedor; origin, se presente, restringe os sites que podem usar o token.
+type tokenClaims struct {
+	Iss    string   `json:"iss"`
+	Iat    *int64   `json:"iat"`
+	Exp    *int64   `json:"exp"`
+	Origin []string `json:"origin"`
+}
+
+// authenticate rejeita as requisições sem um developer token válido e
+// disponibiliza o token verificado no contexto da requisição
+func (a *DeveloperTokenAuth) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, domain.Unauthorized("a developer token is required in the Authorization header"))
+			return
+		}
+		if err := a.verify(token, r.Header.Get("Origin")); err != nil {
+			writeError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), developerTokenContextKey{}, token)))
+	})
+}
+
+type developerTokenContextKey struct{}
+
+// developerTokenFrom retorna o developer token verificado da requisição, ou
+// vazio se a autenticação estiver desabilitada
+func developerTokenFrom(ctx context.Context) string {
+	token, _ := ctx.Value(developerTokenContextKey{}).(string)
+	return token
+}
+
+// verify valida a assinatura e as claims do token para uma requisição da origem informada
+func (a *DeveloperTokenAuth) verify(token, origin string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return domain.Unauthorized("developer token is not a valid JWT")
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return domain.Unauthorized("developer token header is invalid")
+	}
+	if header.Alg != "ES256" {
+		return domain.Unauthorized(fmt.Sprintf("developer token must be signed with ES256, not %q", header.Alg))
+	}
+	key, ok := a.keys[header.Kid]
+	if !ok {
+		return domain.Unauthorized(fmt.Sprintf("developer token key %q is unknown", header.Kid))
+	}
+
+	// A assinatura ES256 é a concatenação de r e s, com 32 bytes cada
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return domain.Unauthorized("developer token signature is invalid")
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	rInt, sInt := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(key, hash[:], rInt, sInt) {
+		return domain.Unauthorized("developer token signature is invalid")
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return domain.Unauthorized("developer token claims are invalid")
+	}
+	if claims.Iss == "" || claims.Iat == nil || claims.Exp == nil {
+		return domain.Unauthorized("developer token must have iss, iat and exp claims")
+	}
+	now := a.now()
+	iat, exp := time.Unix(*claims.Iat, 0), time.Unix(*claims.Exp, 0)
+	switch {
+	case !exp.After(now):
+		return domain.Unauthorized("developer token has expired")
+	case iat.After(now):
+		return domain.Unauthorized("developer token was issued in the future")
+	case exp.Sub(iat) > MaxDeveloperTokenLifetime:
+		return domain.Unauthorized("developer token must expire within 6 months of being issued")
+	}
+
+	if len(claims.Origin) > 0 && !slices.Contains(claims.Origin, origin) {
+		return domain.Forbidden(fmt.Sprintf("developer token does not allow requests from origin %q", origin))
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// LoadDeveloperKeys carrega as chaves públicas aceitas de um arquivo PEM ou de um
+// diretório com um arquivo por chave. O kid é o nome do arquivo sem a extensão e
+// sem o prefixo "AuthKey_", como nas chaves baixadas do portal da Apple. As chaves
+// privadas (.p8) também são aceitas e apenas a parte pública delas é usada.
+func LoadDeveloperKeys(path string) (map[string]*ecdsa.PublicKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading developer keys: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading developer keys: %w", err)
+		}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".pem", ".p8":
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	keys := make(map[string]*ecdsa.PublicKey)
+	for _, file := range files {
+		key, err := loadDeveloperKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading developer key %s: %w", file, err)
+		}
+		name := filepath.Base(file)
+		kid := strings.TrimPrefix(strings.TrimSuffix(name, filepath.Ext(name)), "AuthKey_")
+		keys[kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no developer keys (.pem or .p8) found in %s", path)
+	}
+	return keys, nil
+}
+
+func loadDeveloperKey(file string) (*ecdsa.PublicKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var key any
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "PRIVATE KEY":
+		var private any
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if ecKey, ok := private.(*ecdsa.PrivateKey); ok {
+			key = &ecKey.PublicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, errors.New("key must be an ECDSA P-256 key")
+	}
+	return ecKey, nil
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signToken gera um JWT ES256 com o header e as claims informados
+func signToken(t *testing.T, key *ecdsa.PrivateKey, header, claims map[string]any) string {
+	t.Helper()
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	hash := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestDeveloperTokenAuth(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	auth := NewDeveloperTokenAuth(map[string]*ecdsa.PublicKey{"ABC123DEFG": &key.PublicKey})
+	auth.now = func() time.Time { return now }
+	router := Router(Handlers{Storefront: NewStorefrontHandler(services.NewStorefrontService())}, WithDeveloperTokenAuth(auth))
+
+	header := map[string]any{"alg": "ES256", "kid": "ABC123DEFG"}
+	claims := func(extra map[string]any) map[string]any {
+		c := map[string]any{"iss": "TEAMID1234", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+	valid := signToken(t, key, header, claims(nil))
+
+	tests := []struct {
+		name           string
+		authorization  string
+		origin         string
+		expectedStatus int
+	}{
+		{"Valid token", "Bearer " + valid, "", http.StatusOK},
+		{"Missing header", "", "", http.StatusUnauthorized},
+		{"Not a bearer token", "Basic " + valid, "", http.StatusUnauthorized},
+		{"Malformed token", "Bearer not-a-jwt", "", http.StatusUnauthorized},
+		{"Unknown key", "Bearer " + signToken(t, key, map[string]any{"alg": "ES256", "kid": "OTHER"}, claims(nil)), "", http.StatusUnauthorized},
+		{"Wrong algorithm", "Bearer " + signToken(t, key, map[string]any{"alg": "HS256", "kid": "ABC123DEFG"}, claims(nil)), "", http.StatusUnauthorized},
+		{"Wrong signing key", "Bearer " + signToken(t, otherKey, header, claims(nil)), "", http.StatusUnauthorized},
+		{"Missing issuer", "Bearer " + signToken(t, key, header, claims(map[string]any{"iss": ""})), "", http.StatusUnauthorized},
+		{"Expired", "Bearer " + signToken(t, key, header, claims(map[string]any{"exp": now.Unix()})), "", http.StatusUnauthorized},
+		{"Issued in the future", "Bearer " + signToken(t, key, header, claims(map[string]any{"iat": now.Add(time.Minute).Unix()})), "", http.StatusUnauthorized},
+		{"Lifetime over 6 months", "Bearer " + signToken(t, key, header, claims(map[string]any{"exp": now.Add(MaxDeveloperTokenLifetime + time.Second).Unix()})), "", http.StatusUnauthorized},
+		{"Allowed origin", "Bearer " + signToken(t, key, header, claims(map[string]any{"origin": []string{"https://example.com"}})), "https://example.com", http.StatusOK},
+		{"Disallowed origin", "Bearer " + signToken(t, key, header, claims(map[string]any{"origin": []string{"https://example.com"}})), "https://evil.example", http.StatusForbidden},
+		{"Missing origin", "Bearer " + signToken(t, key, header, claims(map[string]any{"origin": []string{"https://example.com"}})), "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v1/storefronts/us", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				var response errorResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				if assert.Len(t, response.Errors, 1) {
+					assert.NotEmpty(t, response.Errors[0].Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadDeveloperKeys(t *testing.T) {
+	dir := t.TempDir()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	public, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	writePEM := func(name, blockType string, der []byte) {
+		data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o600))
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	writePEM("AuthKey_PRIVATE001.p8", "PRIVATE KEY", der)
+	der, err = x509.MarshalPKIXPublicKey(&public.PublicKey)
+	require.NoError(t, err)
+	writePEM("PUBLIC0001.pem", "PUBLIC KEY", der)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("ignored"), 0o600))
+
+	keys, err := LoadDeveloperKeys(dir)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.True(t, keys["PRIVATE001"].Equal(&private.PublicKey))
+	assert.True(t, keys["PUBLIC0001"].Equal(&public.PublicKey))
+
+	keys, err = LoadDeveloperKeys(filepath.Join(dir, "PUBLIC0001.pem"))
+	require.NoError(t, err)
+	assert.Contains(t, keys, "PUBLIC0001")
+
+	_, err = LoadDeveloperKeys(t.TempDir())
+	assert.Error(t, err, "an empty directory has no keys")
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(&p384.PublicKey)
+	require.NoError(t, err)
+	writePEM("P384.pem", "PUBLIC KEY", der)
+	_, err = LoadDeveloperKeys(filepath.Join(dir, "P384.pem"))
+	assert.ErrorContains(t, err, "P-256")
+}
@@ -0,0 +1,294 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// CatalogHandler lida com as requisições de recursos do catálogo
+type CatalogHandler struct {
+	musicService driving.MusicService
+	options      handlerOptions
+}
+
+// NewCatalogHandler cria uma nova instância do handler de catálogo
+func NewCatalogHandler(musicService driving.MusicService, opts ...Option) *CatalogHandler {
+	return &CatalogHandler{
+		musicService: musicService,
+		options:      newHandlerOptions(opts),
+	}
+}
+
+// GetSong processa a requisição de uma música pelo ID
+func (h *CatalogHandler) GetSong(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	catalog, err := h.options.query(r).catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	song, err := h.musicService.GetSong(ctx, catalog, pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Song]{Data: []domain.Song{*song}})
+}
+
+// GetAlbum processa a requisição de um álbum pelo ID
+func (h *CatalogHandler) GetAlbum(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	catalog, err := h.options.query(r).catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	album, err := h.musicService.GetAlbum(ctx, catalog, pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Album]{Data: []domain.Album{*album}})
+}
+
+// GetArtist processa a requisição de um artista pelo ID
+func (h *CatalogHandler) GetArtist(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	catalog, err := h.options.query(r).catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	artist, err := h.musicService.GetArtist(ctx, catalog, pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Artist]{Data: []domain.Artist{*artist}})
+}
+
+// GetSongs processa a requisição de múltiplas músicas pelo parâmetro ids ou filter[isrc]
+func (h *CatalogHandler) GetSongs(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if q.has("filter[isrc]") {
+		h.getSongsByISRC(ctx, w, q, catalog)
+		return
+	}
+
+	ids, err := q.list("ids", driving.MaxSongIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	songs, err := h.musicService.GetSongs(ctx, catalog, ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Song]{Data: songs})
+}
+
+// GetAlbums processa a requisição de múltiplos álbuns pelo parâmetro ids ou filter[upc]
+func (h *CatalogHandler) GetAlbums(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if q.has("filter[upc]") {
+		h.getAlbumsByUPC(ctx, w, q, catalog)
+		return
+	}
+
+	ids, err := q.list("ids", driving.MaxAlbumIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	albums, err := h.musicService.GetAlbums(ctx, catalog, ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Album]{Data: albums})
+}
+
+// GetArtists processa a requisição de múltiplos artistas pelo parâmetro ids
+func (h *CatalogHandler) GetArtists(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ids, err := q.list("ids", driving.MaxArtistIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	artists, err := h.musicService.GetArtists(ctx, catalog, ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Artist]{Data: artists})
+}
+
+// getSongsByISRC processa a busca de músicas pelo parâmetro filter[isrc]
+func (h *CatalogHandler) getSongsByISRC(ctx context.Context, w http.ResponseWriter, q queryParams, catalog driving.CatalogParameters) {
+	isrcs, err := q.list("filter[isrc]", driving.MaxSongIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for i, isrc := range isrcs {
+		isrcs[i] = strings.ToUpper(isrc)
+		if !domain.ValidISRC(isrcs[i]) {
+			writeError(w, domain.InvalidParameter("filter[isrc]", fmt.Sprintf("%s is not a valid ISRC", isrc)))
+			return
+		}
+	}
+
+	songs, err := h.musicService.GetSongsByISRC(ctx, catalog, isrcs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Song]{Data: songs})
+}
+
+// getAlbumsByUPC processa a busca de álbuns pelo parâmetro filter[upc]
+func (h *CatalogHandler) getAlbumsByUPC(ctx context.Context, w http.ResponseWriter, q queryParams, catalog driving.CatalogParameters) {
+	upcs, err := q.list("filter[upc]", driving.MaxAlbumIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for _, upc := range upcs {
+		if !domain.ValidUPC(upc) {
+			writeError(w, domain.InvalidParameter("filter[upc]", fmt.Sprintf("%s is not a valid UPC", upc)))
+			return
+		}
+	}
+
+	albums, err := h.musicService.GetAlbumsByUPC(ctx, catalog, upcs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Album]{Data: albums})
+}
+
+// GetAlbumTracks processa a requisição do relacionamento de faixas de um álbum
+func (h *CatalogHandler) GetAlbumTracks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := q.page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tracks, err := h.musicService.GetAlbumTracks(ctx, catalog, pathParam(r, "id"), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tracks)
+}
+
+// GetArtistAlbums processa a requisição do relacionamento de álbuns de um artista
+func (h *CatalogHandler) GetArtistAlbums(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := q.page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	albums, err := h.musicService.GetArtistAlbums(ctx, catalog, pathParam(r, "id"), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, albums)
+}
+
+// dataResponse representa o envelope {"data":[...]} usado pela Apple Music API
+type dataResponse[T any] struct {
+	Data []T `json:"data"`
+}
+
+// writeJSON serializa v como JSON com o status informado
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		// O status já foi enviado, então resta apenas registrar a falha
+		log.Printf("error encoding response: %v", err)
+	}
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRouter cria o roteador da aplicação usando o mock como serviço de música
+func newTestRouter(musicService driving.MusicService) http.Handler {
+	return Router(Handlers{
+		Search:     NewSearchHandler(musicService),
+		Catalog:    NewCatalogHandler(musicService),
+		Storefront: NewStorefrontHandler(services.NewStorefrontService()),
+	})
+}
+
+func TestCatalogHandler_GetSong(t *testing.T) {
+	song := &domain.Song{
+		ID:   "test-artist-test-track",
+		Type: "songs",
+		Href: "/v1/catalog/us/songs/test-artist-test-track",
+		Attributes: domain.SongAttributes{
+			Name:       "Test Track",
+			ArtistName: "Test Artist",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "Existing song",
+			path:           "/v1/catalog/us/songs/test-artist-test-track",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown song",
+			path:           "/v1/catalog/us/songs/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Provider error",
+			path:           "/v1/catalog/us/songs/test-artist-test-track",
+			mockError:      assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := &mockMusicProvider{song: song, getError: tt.mockError}
+			router := newTestRouter(mockProvider)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response dataResponse[domain.Song]
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("error decoding response: %v", err)
+				}
+				assert.Len(t, response.Data, 1)
+				assert.Equal(t, song.ID, response.Data[0].ID)
+				assert.Equal(t, song.Attributes.Name, response.Data[0].Attributes.Name)
+			}
+		})
+	}
+}
+
+func TestCatalogHandler_GetAlbumAndArtist(t *testing.T) {
+	mockProvider := &mockMusicProvider{
+		album: &domain.Album{
+			ID:   "test-artist-test-album",
+			Type: "albums",
+			Attributes: domain.AlbumAttributes{
+				Name:       "Test Album",
+				TrackCount: 10,
+				GenreNames: []string{"Rock"},
+			},
+		},
+		artist: &domain.Artist{
+			ID:   "test-artist",
+			Type: "artists",
+			Attributes: domain.ArtistAttributes{
+				Name:       "Test Artist",
+				GenreNames: []string{"Rock"},
+			},
+		},
+	}
+	router := newTestRouter(mockProvider)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedID     string
+	}{
+		{"Existing album", "/v1/catalog/us/albums/test-artist-test-album", http.StatusOK, "test-artist-test-album"},
+		{"Unknown album", "/v1/catalog/us/albums/unknown", http.StatusNotFound, ""},
+		{"Existing artist", "/v1/catalog/us/artists/test-artist", http.StatusOK, "test-artist"},
+		{"Unknown artist", "/v1/catalog/us/artists/unknown", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+
+			if tt.expectedID != "" {
+				var response dataResponse[struct {
+					ID string `json:"id"`
+				}]
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("error decoding response: %v", err)
+				}
+				assert.Len(t, response.Data, 1)
+				assert.Equal(t, tt.expectedID, response.Data[0].ID)
+			}
+		})
+	}
+}
+
+func TestCatalogHandler_Relationships(t *testing.T) {
+	mockProvider := &mockMusicProvider{
+		album:  &domain.Album{ID: "test-artist-test-album", Type: "albums"},
+		artist: &domain.Artist{ID: "test-artist", Type: "artists"},
+		tracks: []domain.Song{{ID: "test-artist-track-1", Type: "songs"}},
+	}
+	router := newTestRouter(mockProvider)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedPage   driving.PageParameters
+	}{
+		{"Album tracks with default paging", "/v1/catalog/us/albums/test-artist-test-album/tracks", http.StatusOK, driving.PageParameters{Limit: 25}},
+		{"Artist albums with paging", "/v1/catalog/us/artists/test-artist/albums?limit=10&offset=20", http.StatusOK, driving.PageParameters{Limit: 10, Offset: 20}},
+		{"Limit above maximum", "/v1/catalog/us/artists/test-artist/albums?limit=500", http.StatusBadRequest, driving.PageParameters{}},
+		{"Invalid offset", "/v1/catalog/us/artists/test-artist/albums?offset=abc", http.StatusBadRequest, driving.PageParameters{}},
+		{"Unknown album", "/v1/catalog/us/albums/unknown/tracks", http.StatusNotFound, driving.PageParameters{Limit: 25}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider.lastPage = driving.PageParameters{}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+			assert.Equal(t, tt.expectedPage, mockProvider.lastPage)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Href string            `json:"href"`
+					Data []json.RawMessage `json:"data"`
+				}
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("error decoding response: %v", err)
+				}
+				assert.NotEmpty(t, response.Href)
+				assert.Len(t, response.Data, 1)
+			}
+		})
+	}
+}
+
+func TestCatalogHandler_GetSongs(t *testing.T) {
+	mockProvider := &mockMusicProvider{song: &domain.Song{ID: "test-artist-test-track", Type: "songs"}}
+	router := newTestRouter(mockProvider)
+
+	tooMany := make([]string, driving.MaxSongIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("song-%d", i)
+	}
+
+	tests := []struct {
+		name           string
+		ids            string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{"Known and unknown ids", "unknown,test-artist-test-track", http.StatusOK, 1},
+		{"Duplicated ids", "test-artist-test-track,test-artist-test-track", http.StatusOK, 1},
+		{"Only unknown ids", "unknown", http.StatusOK, 0},
+		{"Missing ids", "", http.StatusBadRequest, 0},
+		{"Too many ids", strings.Join(tooMany, ","), http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v1/catalog/us/songs", nil)
+			if tt.ids != "" {
+				req.URL.RawQuery = "ids=" + tt.ids
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response dataResponse[domain.Song]
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("error decoding response: %v", err)
+				}
+				assert.NotNil(t, response.Data)
+				assert.Len(t, response.Data, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestCatalogHandler_Filters(t *testing.T) {
+	mockProvider := &mockMusicProvider{
+		song:  &domain.Song{ID: "test-artist-test-track", Type: "songs", Attributes: domain.SongAttributes{ISRC: "USAMS1212345"}},
+		album: &domain.Album{ID: "test-artist-test-album", Type: "albums", Attributes: domain.AlbumAttributes{UPC: "036000291452"}},
+	}
+	router := newTestRouter(mockProvider)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{"Song by isrc", "/v1/catalog/us/songs?filter[isrc]=usams1212345", http.StatusOK, 1},
+		{"Unknown isrc", "/v1/catalog/us/songs?filter[isrc]=USAMS9999999", http.StatusOK, 0},
+		{"Invalid isrc", "/v1/catalog/us/songs?filter[isrc]=US-AMS-12", http.StatusBadRequest, 0},
+		{"Album by upc", "/v1/catalog/us/albums?filter[upc]=036000291452", http.StatusOK, 1},
+		{"Invalid upc check digit", "/v1/catalog/us/albums?filter[upc]=036000291453", http.StatusBadRequest, 0},
+		{"Empty filter", "/v1/catalog/us/albums?filter[upc]=", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response dataResponse[json.RawMessage]
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("error decoding response: %v", err)
+				}
+				assert.Len(t, response.Data, tt.expectedCount)
+			}
+		})
+	}
+}
+
+// slowMusicService simula um provedor que só responde quando o contexto termina
+type slowMusicService struct {
+	*mockMusicProvider
+}
+
+func (s *slowMusicService) GetSong(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Song, error) {
+	<-ctx.Done()
+	return nil, domain.Timeout("Last.fm request timed out", ctx.Err())
+}
+
+func TestCatalogHandler_RequestTimeout(t *testing.T) {
+	musicService := &slowMusicService{&mockMusicProvider{}}
+	router := Router(Handlers{Catalog: NewCatalogHandler(musicService, WithRequestTimeout(20*time.Millisecond))})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/catalog/us/songs/slow", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"50400"`)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// ChartHandler lida com as requisições dos rankings do catálogo
+type ChartHandler struct {
+	chartService driving.ChartService
+	options      handlerOptions
+}
+
+// NewChartHandler cria uma nova instância do handler de rankings
+func NewChartHandler(chartService driving.ChartService, opts ...Option) *ChartHandler {
+	return &ChartHandler{
+		chartService: chartService,
+		options:      newHandlerOptions(opts),
+	}
+}
+
+// chartsResponse representa a resposta dos rankings no formato da Apple Music API
+type chartsResponse struct {
+	Results *driving.ChartResults `json:"results"`
+}
+
+// GetCharts processa a requisição dos rankings dos tipos do parâmetro types
+func (h *ChartHandler) GetCharts(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	types, err := chartTypes(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	chart, err := q.get("chart")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if chart != "" && chart != driving.MostPlayedChart && q.strict {
+		writeError(w, domain.InvalidParameter("chart", fmt.Sprintf("chart %s is not supported (expected %s)", chart, driving.MostPlayedChart)))
+		return
+	}
+
+	genre, err := q.genre()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := q.pageWithin(driving.DefaultChartLimit, driving.MaxChartLimit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results, err := h.chartService.GetCharts(ctx, driving.ChartParameters{
+		CatalogParameters: catalog,
+		Types:             types,
+		Genre:             genre,
+		Page:              page,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, chartsResponse{Results: results})
+}
+
+// chartTypes obtém os tipos do parâmetro types, que é obrigatório. No modo
+// estrito, tipos sem ranking são rejeitados; no leniente, ignorados.
+func chartTypes(q queryParams) ([]driving.SearchResultType, error) {
+	typesStr, err := q.get("types")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(typesStr) == "" {
+		return nil, domain.InvalidParameter("types", "types parameter is required")
+	}
+
+	var types []driving.SearchResultType
+	seen := make(map[driving.SearchResultType]bool)
+	for _, t := range strings.Split(typesStr, ",") {
+		chartType := driving.SearchResultType(strings.TrimSpace(t))
+		switch chartType {
+		case driving.SongsType, driving.AlbumsType:
+			if !seen[chartType] {
+				seen[chartType] = true
+				types = append(types, chartType)
+			}
+		default:
+			if q.strict {
+				return nil, domain.InvalidParameter("types", fmt.Sprintf("%s is not a supported type", chartType))
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return nil, domain.InvalidParameter("types", "types must contain at least one of songs or albums")
+	}
+	return types, nil
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applemusic-api-simulator/internal/adapters/driven/fixture"
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChartRouter cria um roteador com a rota dos rankings servindo as fixtures do adaptador
+func newChartRouter(t *testing.T, opts ...Option) http.Handler {
+	t.Helper()
+	provider, err := fixture.NewFixtureAdapter("../../driven/fixture/testdata")
+	require.NoError(t, err)
+	return Router(Handlers{Chart: NewChartHandler(services.NewChartService(provider), opts...)})
+}
+
+func TestChartHandler_GetCharts(t *testing.T) {
+	router := newChartRouter(t)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/catalog/us/charts?types=songs,albums&limit=2", nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var response struct {
+		Results struct {
+			Songs []struct {
+				Chart   string        `json:"chart"`
+				Name    string        `json:"name"`
+				OrderID string        `json:"orderId"`
+				Href    string        `json:"href"`
+				Next    string        `json:"next"`
+				Data    []domain.Song `json:"data"`
+			} `json:"songs"`
+			Albums []struct {
+				Name string         `json:"name"`
+				Data []domain.Album `json:"data"`
+			} `json:"albums"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	require.Len(t, response.Results.Songs, 1)
+	songs := response.Results.Songs[0]
+	assert.Equal(t, "most-played", songs.Chart)
+	assert.Equal(t, "Top Songs", songs.Name)
+	assert.Equal(t, "most-played:songs", songs.OrderID)
+	assert.Equal(t, "/v1/catalog/us/charts?chart=most-played&types=songs", songs.Href)
+	assert.Equal(t, "/v1/catalog/us/charts?chart=most-played&types=songs&offset=2&limit=2", songs.Next)
+	require.Len(t, songs.Data, 2)
+	assert.Equal(t, "/v1/catalog/us/songs/second-song", songs.Data[0].Href)
+	require.Len(t, response.Results.Albums, 1)
+	assert.Equal(t, "Top Albums", response.Results.Albums[0].Name)
+	assert.Len(t, response.Results.Albums[0].Data, 1)
+
+	// Filtrando por gênero (Rock), a música avulsa sai do ranking
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/catalog/us/charts?types=songs&genre=21", nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Len(t, response.Results.Songs[0].Data, 2)
+}
+
+func TestChartHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		mode   ValidationMode
+		status int
+	}{
+		{"Missing types", "/v1/catalog/us/charts", StrictValidation, http.StatusBadRequest},
+		{"Unsupported type", "/v1/catalog/us/charts?types=songs,stations", StrictValidation, http.StatusBadRequest},
+		{"Unsupported type in lenient mode", "/v1/catalog/us/charts?types=songs,stations", LenientValidation, http.StatusOK},
+		{"Unsupported chart", "/v1/catalog/us/charts?types=songs&chart=daily-global-top", StrictValidation, http.StatusBadRequest},
+		{"Unknown genre", "/v1/catalog/us/charts?types=songs&genre=Rock", StrictValidation, http.StatusBadRequest},
+		{"Unknown genre in lenient mode", "/v1/catalog/us/charts?types=songs&genre=Rock", LenientValidation, http.StatusOK},
+		{"Limit above maximum", "/v1/catalog/us/charts?types=songs&limit=51", StrictValidation, http.StatusBadRequest},
+		{"Unknown storefront", "/v1/catalog/xx/charts?types=songs", StrictValidation, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			newChartRouter(t, WithValidationMode(tt.mode)).ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
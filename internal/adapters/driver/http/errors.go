@@ -0,0 +1,95 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// errorResponse representa o envelope de erros da Apple Music API
+type errorResponse struct {
+	Errors []errorObject `json:"errors"`
+}
+
+// errorObject representa um erro no formato da Apple Music API
+type errorObject struct {
+	ID     string       `json:"id"`
+	Title  string       `json:"title"`
+	Detail string       `json:"detail"`
+	Status string       `json:"status"`
+	Code   string       `json:"code"`
+	Source *errorSource `json:"source,omitempty"`
+}
+
+// errorSource indica o parâmetro da requisição que causou o erro
+type errorSource struct {
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// errorStatus descreve como cada tipo de erro é apresentado ao cliente
+type errorStatus struct {
+	status int
+	title  string
+	code   string
+}
+
+var errorStatuses = map[domain.ErrorKind]errorStatus{
+	domain.KindInvalidParameter: {http.StatusBadRequest, "Invalid Parameter Value", "40005"},
+	domain.KindUnauthorized:     {http.StatusUnauthorized, "Unauthorized", "40100"},
+	domain.KindForbidden:        {http.StatusForbidden, "Forbidden", "40300"},
+	domain.KindNotFound:         {http.StatusNotFound, "Resource Not Found", "40400"},
+	domain.KindRateLimited:      {http.StatusTooManyRequests, "API Capacity Exceeded", "42900"},
+	domain.KindUpstream:         {http.StatusBadGateway, "Upstream Service Error", "50200"},
+	domain.KindTimeout:          {http.StatusGatewayTimeout, "Gateway Timeout", "50400"},
+	domain.KindInternal:         {http.StatusInternalServerError, "Internal Server Error", "50000"},
+}
+
+// writeError responde com o erro no formato da Apple Music API,
+// escolhendo o status HTTP a partir da classificação do erro
+func writeError(w http.ResponseWriter, err error) {
+	obj, status := newErrorObject(err)
+	writeJSON(w, status, errorResponse{Errors: []errorObject{obj}})
+}
+
+// newErrorObject converte um erro no formato da Apple Music API e retorna
+// também o status HTTP correspondente
+func newErrorObject(err error) (errorObject, int) {
+	kind := domain.KindOf(err)
+	status := errorStatuses[kind]
+
+	// Erros do cliente usam apenas o detalhe do erro do domínio; falhas internas
+	// e do provedor mantêm a cadeia completa para facilitar o diagnóstico
+	detail := err.Error()
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) && kind != domain.KindInternal && kind != domain.KindUpstream && kind != domain.KindTimeout {
+		detail = domainErr.Detail
+	}
+
+	obj := errorObject{
+		ID:     newErrorID(),
+		Title:  status.title,
+		Detail: detail,
+		Status: strconv.Itoa(status.status),
+		Code:   status.code,
+	}
+	if parameter := domain.ParameterOf(err); parameter != "" {
+		obj.Source = &errorSource{Parameter: parameter}
+	}
+	return obj, status.status
+}
+
+// notFound responde 404 para rotas inexistentes
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, domain.NotFound("the requested resource does not exist"))
+}
+
+// newErrorID gera um identificador aleatório para um erro, como os da Apple
+func newErrorID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name              string
+		err               error
+		expectedStatus    int
+		expectedCode      string
+		expectedParameter string
+	}{
+		{"Invalid parameter", domain.InvalidParameter("limit", "limit must be an integer"), http.StatusBadRequest, "40005", "limit"},
+		{"Unauthorized", domain.Unauthorized("developer token has expired"), http.StatusUnauthorized, "40100", ""},
+		{"Forbidden", domain.Forbidden("origin is not allowed"), http.StatusForbidden, "40300", ""},
+		{"Not found", fmt.Errorf("wrapped: %w", domain.NotFound("song x not found")), http.StatusNotFound, "40400", ""},
+		{"Not found sentinel", domain.ErrNotFound, http.StatusNotFound, "40400", ""},
+		{"Rate limited", domain.RateLimited("slow down"), http.StatusTooManyRequests, "42900", ""},
+		{"Upstream", domain.UpstreamError("Last.fm request failed", assert.AnError), http.StatusBadGateway, "50200", ""},
+		{"Timeout", domain.Timeout("Last.fm request timed out", context.DeadlineExceeded), http.StatusGatewayTimeout, "50400", ""},
+		{"Context deadline", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, "50400", ""},
+		{"Unclassified", assert.AnError, http.StatusInternalServerError, "50000", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			writeError(rr, tt.err)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+			var response errorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("error decoding response: %v", err)
+			}
+			if assert.Len(t, response.Errors, 1) {
+				e := response.Errors[0]
+				assert.NotEmpty(t, e.ID)
+				assert.NotEmpty(t, e.Title)
+				assert.NotEmpty(t, e.Detail)
+				assert.Equal(t, fmt.Sprint(tt.expectedStatus), e.Status)
+				assert.Equal(t, tt.expectedCode, e.Code)
+				if tt.expectedParameter == "" {
+					assert.Nil(t, e.Source)
+				} else if assert.NotNil(t, e.Source) {
+					assert.Equal(t, tt.expectedParameter, e.Source.Parameter)
+				}
+			}
+		})
+	}
+}
+
+func TestRouter_ErrorEnvelope(t *testing.T) {
+	router := newTestRouter(&mockMusicProvider{})
+
+	tests := []struct {
+		name              string
+		path              string
+		expectedStatus    int
+		expectedParameter string
+	}{
+		{"Invalid search limit", "/v1/catalog/us/search?term=test&limit=abc", http.StatusBadRequest, "limit"},
+		{"Invalid search offset", "/v1/catalog/us/search?term=test&offset=abc", http.StatusBadRequest, "offset"},
+		{"Invalid search types", "/v1/catalog/us/search?term=test&types=videos", http.StatusBadRequest, "types"},
+		{"Unsupported language", "/v1/catalog/us/songs/test?l=ja", http.StatusBadRequest, "l"},
+		{"Unknown song", "/v1/catalog/us/songs/unknown", http.StatusNotFound, ""},
+		{"Unknown route", "/v1/unknown", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			var response errorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("error decoding response: %v", err)
+			}
+			if assert.Len(t, response.Errors, 1) && tt.expectedParameter != "" {
+				assert.Equal(t, tt.expectedParameter, response.Errors[0].Source.Parameter)
+			}
+		})
+	}
+}
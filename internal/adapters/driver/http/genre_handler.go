@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// GenreHandler lida com as requisições dos gêneros do catálogo
+type GenreHandler struct {
+	genreService driving.GenreService
+	options      handlerOptions
+}
+
+// NewGenreHandler cria uma nova instância do handler de gêneros
+func NewGenreHandler(genreService driving.GenreService, opts ...Option) *GenreHandler {
+	return &GenreHandler{
+		genreService: genreService,
+		options:      newHandlerOptions(opts),
+	}
+}
+
+// GetGenres processa a requisição de múltiplos gêneros pelo parâmetro ids ou,
+// sem ele, a dos gêneros principais usados nos rankings
+func (h *GenreHandler) GetGenres(w http.ResponseWriter, r *http.Request) {
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if !q.has("ids") {
+		writeJSON(w, http.StatusOK, dataResponse[domain.Genre]{Data: h.genreService.ListTopGenres(catalog)})
+		return
+	}
+
+	ids, err := q.list("ids", driving.MaxGenreIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Genre]{Data: h.genreService.GetGenres(catalog, ids)})
+}
+
+// GetGenre processa a requisição de um gênero pelo ID
+func (h *GenreHandler) GetGenre(w http.ResponseWriter, r *http.Request) {
+	catalog, err := h.options.query(r).catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	genre, err := h.genreService.GetGenre(catalog, pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Genre]{Data: []domain.Genre{*genre}})
+}
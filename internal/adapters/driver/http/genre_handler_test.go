@@ -0,0 +1,67 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenreHandler(t *testing.T) {
+	router := Router(Handlers{Genre: NewGenreHandler(services.NewGenreService())})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedIDs    []string
+	}{
+		{"Single genre", "/v1/catalog/br/genres/1152", http.StatusOK, []string{"1152"}},
+		{"Multiple genres", "/v1/catalog/br/genres?ids=14,999,21", http.StatusOK, []string{"14", "21"}},
+		{"Unknown genre", "/v1/catalog/br/genres/999", http.StatusNotFound, nil},
+		{"Empty ids", "/v1/catalog/br/genres?ids=", http.StatusBadRequest, nil},
+		{"Unknown storefront", "/v1/catalog/xx/genres", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			require.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response dataResponse[domain.Genre]
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			var ids []string
+			for _, g := range response.Data {
+				ids = append(ids, g.ID)
+				assert.Equal(t, "/v1/catalog/br/genres/"+g.ID, g.Href)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGenreHandler_TopGenres(t *testing.T) {
+	router := Router(Handlers{Genre: NewGenreHandler(services.NewGenreService())})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/catalog/us/genres", nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var response dataResponse[domain.Genre]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	require.NotEmpty(t, response.Data)
+	assert.Equal(t, domain.MusicGenreID, response.Data[0].ID)
+	for _, g := range response.Data[1:] {
+		assert.Equal(t, "Music", g.Attributes.ParentName, "genre %s", g.ID)
+	}
+}
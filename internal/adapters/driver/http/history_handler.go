@@ -0,0 +1,125 @@
+package http
+
+import (
+	"net/http"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// HistoryHandler lida com as requisições do histórico de reprodução do usuário.
+// As rotas exigem o Music-User-Token verificado pelo MeHandler.
+type HistoryHandler struct {
+	historyService driving.HistoryService
+	options        handlerOptions
+}
+
+// NewHistoryHandler cria uma nova instância do handler de histórico
+func NewHistoryHandler(historyService driving.HistoryService, opts ...Option) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+		options:        newHandlerOptions(opts),
+	}
+}
+
+// station representa uma estação de rádio no formato da Apple Music API
+type station struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	Href       string            `json:"href"`
+	Attributes stationAttributes `json:"attributes"`
+}
+
+// stationAttributes representa os atributos de uma estação de rádio
+type stationAttributes struct {
+	Name       string            `json:"name"`
+	IsLive     bool              `json:"isLive"`
+	PlayParams domain.PlayParams `json:"playParams"`
+}
+
+// newPlayedResources converte os containers tocados nos recursos da Apple Music
+// API, que formam uma lista com tipos misturados
+func newPlayedResources(page *driving.Page[driving.PlayedResource], storefront string) driving.Page[any] {
+	data := make([]any, 0, len(page.Data))
+	for _, resource := range page.Data {
+		switch {
+		case resource.Album != nil:
+			data = append(data, resource.Album)
+		case resource.Playlist != nil:
+			data = append(data, newLibraryPlaylist(resource.Playlist, storefront, false))
+		case resource.Station != nil:
+			data = append(data, station{
+				ID:   resource.Station.ID,
+				Type: "stations",
+				Href: domain.CatalogHref(storefront, "stations", resource.Station.ID),
+				Attributes: stationAttributes{
+					Name:       resource.Station.Name,
+					PlayParams: domain.PlayParams{ID: resource.Station.ID, Kind: "radioStation"},
+				},
+			})
+		}
+	}
+	return driving.Page[any]{Href: page.Href, Next: page.Next, Data: data}
+}
+
+// RecentlyPlayed processa a requisição dos álbuns, playlists e estações tocados recentemente
+func (h *HistoryHandler) RecentlyPlayed(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).pageWithin(driving.DefaultHistoryLimit, driving.MaxRecentPlayedLimit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user := userFrom(ctx)
+	resources, err := h.historyService.RecentlyPlayed(ctx, user, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPlayedResources(resources, user.Storefront))
+}
+
+// RecentlyPlayedTracks processa a requisição das músicas tocadas recentemente
+func (h *HistoryHandler) RecentlyPlayedTracks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).pageWithin(driving.DefaultHistoryLimit, driving.MaxRecentTracksLimit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tracks, err := h.historyService.RecentlyPlayedTracks(ctx, userFrom(ctx), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tracks)
+}
+
+// HeavyRotation processa a requisição dos álbuns, playlists e estações mais tocados
+func (h *HistoryHandler) HeavyRotation(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).pageWithin(driving.DefaultHistoryLimit, driving.MaxHeavyRotationLimit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user := userFrom(ctx)
+	resources, err := h.historyService.HeavyRotation(ctx, user, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPlayedResources(resources, user.Storefront))
+}
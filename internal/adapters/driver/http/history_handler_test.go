@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playedResource reúne os campos comuns aos recursos das listas de histórico
+type playedResource struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Href       string `json:"href"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+// recordPlays registra eventos de reprodução pela rota de administração
+func recordPlays(router http.Handler, userID, body string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/users/"+userID+"/plays", strings.NewReader(body)))
+	return rr
+}
+
+func TestHistoryHandler(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	rr := serveUser(router, token, "POST", "/v1/me/library/playlists", `{"attributes": {"name": "Road Trip"}}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created dataResponse[libraryPlaylist]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&created))
+	playlistID := created.Data[0].ID
+
+	rr = recordPlays(router, "alice", `{"data": [
+		{"songId": "first-song", "playedAt": "2024-01-01T10:00:00Z", "container": {"type": "albums", "id": "test-album"}},
+		{"songId": "first-song", "playedAt": "2024-01-01T11:00:00Z", "container": {"type": "albums", "id": "test-album"}},
+		{"songId": "second-song", "playedAt": "2024-01-01T12:00:00Z", "container": {"type": "playlists", "id": "`+playlistID+`"}},
+		{"songId": "second-song", "container": {"type": "stations", "id": "ra.1", "name": "Test Radio"}}
+	]}`)
+	require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+	rr = serveUser(router, token, "GET", "/v1/me/recent/played", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var recent struct {
+		Data []playedResource `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&recent))
+	require.Len(t, recent.Data, 3)
+	assert.Equal(t, "stations", recent.Data[0].Type)
+	assert.Equal(t, "/v1/catalog/us/stations/ra.1", recent.Data[0].Href)
+	assert.Equal(t, "Test Radio", recent.Data[0].Attributes.Name)
+	assert.Equal(t, "library-playlists", recent.Data[1].Type)
+	assert.Equal(t, "Road Trip", recent.Data[1].Attributes.Name)
+	assert.Equal(t, "albums", recent.Data[2].Type)
+	assert.Equal(t, "/v1/catalog/us/albums/test-album", recent.Data[2].Href)
+
+	rr = serveUser(router, token, "GET", "/v1/me/recent/played/tracks?limit=1", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var tracks struct {
+		Next string        `json:"next"`
+		Data []domain.Song `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&tracks))
+	if assert.Len(t, tracks.Data, 1) {
+		assert.Equal(t, "second-song", tracks.Data[0].ID)
+	}
+	assert.Equal(t, "/v1/me/recent/played/tracks?offset=1&limit=1", tracks.Next)
+
+	// Apenas o evento sem playedAt está dentro da janela da heavy rotation
+	rr = serveUser(router, token, "GET", "/v1/me/history/heavy-rotation", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var rotation struct {
+		Data []playedResource `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rotation))
+	if assert.Len(t, rotation.Data, 1) {
+		assert.Equal(t, "ra.1", rotation.Data[0].ID)
+	}
+}
+
+func TestHistoryHandler_Errors(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	tests := []struct {
+		name   string
+		status int
+		rr     *httptest.ResponseRecorder
+	}{
+		{"Limit above maximum", http.StatusBadRequest, serveUser(router, token, "GET", "/v1/me/recent/played?limit=11", "")},
+		{"Tracks limit above maximum", http.StatusBadRequest, serveUser(router, token, "GET", "/v1/me/recent/played/tracks?limit=31", "")},
+		{"Missing user token", http.StatusUnauthorized, serveUser(router, "", "GET", "/v1/me/history/heavy-rotation", "")},
+		{"Unknown song", http.StatusBadRequest, recordPlays(router, "alice", `{"data": [{"songId": "unknown"}]}`)},
+		{"Unknown container type", http.StatusBadRequest, recordPlays(router, "alice", `{"data": [{"songId": "first-song", "container": {"type": "videos", "id": "v.1"}}]}`)},
+		{"Malformed body", http.StatusBadRequest, recordPlays(router, "alice", `{"data":`)},
+		{"Unknown user", http.StatusNotFound, recordPlays(router, "nobody", `{"data": [{"songId": "first-song"}]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, tt.rr.Code, tt.rr.Body.String())
+		})
+	}
+}
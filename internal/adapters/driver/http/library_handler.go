@@ -0,0 +1,590 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// LibraryHandler lida com as requisições da biblioteca do usuário, em
+// /v1/me/library. As rotas exigem o Music-User-Token verificado pelo MeHandler.
+type LibraryHandler struct {
+	libraryService driving.LibraryService
+	options        handlerOptions
+}
+
+// NewLibraryHandler cria uma nova instância do handler da biblioteca
+func NewLibraryHandler(libraryService driving.LibraryService, opts ...Option) *LibraryHandler {
+	return &LibraryHandler{
+		libraryService: libraryService,
+		options:        newHandlerOptions(opts),
+	}
+}
+
+// libraryPlaylist representa uma playlist no formato library-playlists da Apple Music API
+type libraryPlaylist struct {
+	ID            string                        `json:"id"`
+	Type          string                        `json:"type"`
+	Href          string                        `json:"href"`
+	Attributes    libraryPlaylistAttributes     `json:"attributes"`
+	Relationships *libraryPlaylistRelationships `json:"relationships,omitempty"`
+}
+
+// libraryPlaylistAttributes representa os atributos de uma playlist da biblioteca
+type libraryPlaylistAttributes struct {
+	Name             string                 `json:"name"`
+	Description      *domain.EditorialNotes `json:"description,omitempty"`
+	CanEdit          bool                   `json:"canEdit"`
+	IsPublic         bool                   `json:"isPublic"`
+	HasCatalog       bool                   `json:"hasCatalog"`
+	DateAdded        time.Time              `json:"dateAdded"`
+	LastModifiedDate time.Time              `json:"lastModifiedDate"`
+	PlayParams       libraryPlayParams      `json:"playParams"`
+}
+
+// libraryPlayParams representa os parâmetros de reprodução de um recurso da biblioteca
+type libraryPlayParams struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	IsLibrary bool   `json:"isLibrary"`
+	CatalogID string `json:"catalogId,omitempty"`
+}
+
+// libraryPlaylistRelationships representa os relacionamentos de uma playlist da biblioteca
+type libraryPlaylistRelationships struct {
+	Tracks driving.Page[playlistTrack] `json:"tracks"`
+}
+
+// playlistTrack representa uma faixa de playlist, que referencia uma música do catálogo
+type playlistTrack struct {
+	ID         string                  `json:"id"`
+	Type       string                  `json:"type"`
+	Href       string                  `json:"href"`
+	Attributes playlistTrackAttributes `json:"attributes"`
+}
+
+// playlistTrackAttributes representa os atributos de uma faixa de playlist
+type playlistTrackAttributes struct {
+	Name             string          `json:"name"`
+	ArtistName       string          `json:"artistName"`
+	DurationInMillis int             `json:"durationInMillis,omitempty"`
+	Artwork          *domain.Artwork `json:"artwork,omitempty"`
+}
+
+// newLibraryPlaylist converte uma playlist no formato da Apple Music API. As
+// faixas são incluídas como relacionamento apenas se withTracks for verdadeiro.
+func newLibraryPlaylist(playlist *domain.Playlist, storefront string, withTracks bool) libraryPlaylist {
+	resource := libraryPlaylist{
+		ID:   playlist.ID,
+		Type: "library-playlists",
+		Href: domain.LibraryHref("playlists", playlist.ID),
+		Attributes: libraryPlaylistAttributes{
+			Name:             playlist.Name,
+			CanEdit:          true,
+			DateAdded:        playlist.DateAdded,
+			LastModifiedDate: playlist.LastModifiedDate,
+			PlayParams:       libraryPlayParams{ID: playlist.ID, Kind: "playlist", IsLibrary: true},
+		},
+	}
+	if playlist.Description != "" {
+		resource.Attributes.Description = &domain.EditorialNotes{Standard: playlist.Description}
+	}
+
+	if withTracks {
+		tracks := make([]playlistTrack, len(playlist.Tracks))
+		for i := range playlist.Tracks {
+			tracks[i] = newPlaylistTrack(&playlist.Tracks[i], storefront)
+		}
+		resource.Relationships = &libraryPlaylistRelationships{
+			Tracks: driving.Page[playlistTrack]{Href: resource.Href + "/tracks", Data: tracks},
+		}
+	}
+	return resource
+}
+
+// newPlaylistTrack converte uma faixa de playlist, referenciando a música no
+// catálogo do storefront informado
+func newPlaylistTrack(track *domain.Track, storefront string) playlistTrack {
+	resource := playlistTrack{
+		ID:   track.ID,
+		Type: "songs",
+		Href: domain.CatalogHref(storefront, "songs", track.ID),
+		Attributes: playlistTrackAttributes{
+			Name:             track.Title,
+			ArtistName:       track.Artist,
+			DurationInMillis: track.Duration,
+		},
+	}
+	if track.CoverURL != "" {
+		resource.Attributes.Artwork = &domain.Artwork{URL: track.CoverURL}
+	}
+	return resource
+}
+
+// librarySong representa uma música no formato library-songs da Apple Music API
+type librarySong struct {
+	ID            string                                   `json:"id"`
+	Type          string                                   `json:"type"`
+	Href          string                                   `json:"href"`
+	Attributes    librarySongAttributes                    `json:"attributes"`
+	Relationships libraryCatalogRelationships[domain.Song] `json:"relationships"`
+}
+
+// librarySongAttributes representa os atributos de uma música da biblioteca
+type librarySongAttributes struct {
+	Name             string            `json:"name"`
+	ArtistName       string            `json:"artistName"`
+	AlbumName        string            `json:"albumName"`
+	GenreNames       []string          `json:"genreNames"`
+	TrackNumber      int               `json:"trackNumber"`
+	DiscNumber       int               `json:"discNumber"`
+	DurationInMillis int               `json:"durationInMillis"`
+	ReleaseDate      string            `json:"releaseDate,omitempty"`
+	HasLyrics        bool              `json:"hasLyrics"`
+	Artwork          domain.Artwork    `json:"artwork"`
+	DateAdded        time.Time         `json:"dateAdded"`
+	PlayParams       libraryPlayParams `json:"playParams"`
+}
+
+// libraryAlbum representa um álbum no formato library-albums da Apple Music API
+type libraryAlbum struct {
+	ID            string                                    `json:"id"`
+	Type          string                                    `json:"type"`
+	Href          string                                    `json:"href"`
+	Attributes    libraryAlbumAttributes                    `json:"attributes"`
+	Relationships libraryCatalogRelationships[domain.Album] `json:"relationships"`
+}
+
+// libraryAlbumAttributes representa os atributos de um álbum da biblioteca
+type libraryAlbumAttributes struct {
+	Name        string            `json:"name"`
+	ArtistName  string            `json:"artistName"`
+	GenreNames  []string          `json:"genreNames"`
+	ReleaseDate string            `json:"releaseDate,omitempty"`
+	TrackCount  int               `json:"trackCount"`
+	Artwork     domain.Artwork    `json:"artwork"`
+	DateAdded   time.Time         `json:"dateAdded"`
+	PlayParams  libraryPlayParams `json:"playParams"`
+}
+
+// libraryArtist representa um artista no formato library-artists da Apple Music API
+type libraryArtist struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Href       string `json:"href"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+// libraryCatalogRelationships liga um recurso da biblioteca ao recurso do catálogo
+// de onde ele veio
+type libraryCatalogRelationships[T any] struct {
+	Catalog driving.Page[T] `json:"catalog"`
+}
+
+func newLibrarySong(song *domain.LibrarySong) librarySong {
+	href := domain.LibraryHref("songs", song.ID)
+	attributes := song.Song.Attributes
+	return librarySong{
+		ID:   song.ID,
+		Type: "library-songs",
+		Href: href,
+		Attributes: librarySongAttributes{
+			Name:             attributes.Name,
+			ArtistName:       attributes.ArtistName,
+			AlbumName:        attributes.AlbumName,
+			GenreNames:       attributes.GenreNames,
+			TrackNumber:      attributes.TrackNumber,
+			DiscNumber:       attributes.DiscNumber,
+			DurationInMillis: attributes.DurationInMillis,
+			ReleaseDate:      attributes.ReleaseDate,
+			HasLyrics:        attributes.HasLyrics,
+			Artwork:          attributes.Artwork,
+			DateAdded:        song.DateAdded,
+			PlayParams:       libraryPlayParams{ID: song.ID, Kind: "song", IsLibrary: true, CatalogID: song.Song.ID},
+		},
+		Relationships: libraryCatalogRelationships[domain.Song]{
+			Catalog: driving.Page[domain.Song]{Href: href + "/catalog", Data: []domain.Song{song.Song}},
+		},
+	}
+}
+
+func newLibraryAlbum(album *domain.LibraryAlbum) libraryAlbum {
+	href := domain.LibraryHref("albums", album.ID)
+	attributes := album.Album.Attributes
+	return libraryAlbum{
+		ID:   album.ID,
+		Type: "library-albums",
+		Href: href,
+		Attributes: libraryAlbumAttributes{
+			Name:        attributes.Name,
+			ArtistName:  attributes.ArtistName,
+			GenreNames:  attributes.GenreNames,
+			ReleaseDate: attributes.ReleaseDate,
+			TrackCount:  attributes.TrackCount,
+			Artwork:     attributes.Artwork,
+			DateAdded:   album.DateAdded,
+			PlayParams:  libraryPlayParams{ID: album.ID, Kind: "album", IsLibrary: true, CatalogID: album.Album.ID},
+		},
+		Relationships: libraryCatalogRelationships[domain.Album]{
+			Catalog: driving.Page[domain.Album]{Href: href + "/catalog", Data: []domain.Album{album.Album}},
+		},
+	}
+}
+
+func newLibraryArtist(artist *domain.LibraryArtist) libraryArtist {
+	resource := libraryArtist{
+		ID:   artist.ID,
+		Type: "library-artists",
+		Href: domain.LibraryHref("artists", artist.ID),
+	}
+	resource.Attributes.Name = artist.Name
+	return resource
+}
+
+// writePage responde com uma página de recursos da biblioteca convertidos por convert
+func writePage[T, R any](w http.ResponseWriter, page *driving.Page[T], convert func(*T) R) {
+	data := make([]R, len(page.Data))
+	for i := range page.Data {
+		data[i] = convert(&page.Data[i])
+	}
+	writeJSON(w, http.StatusOK, driving.Page[R]{Href: page.Href, Next: page.Next, Data: data})
+}
+
+// resourceIdentifier identifica um recurso no corpo de uma requisição
+type resourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// tracksRequest é o corpo da adição de faixas a uma playlist
+type tracksRequest struct {
+	Data []resourceIdentifier `json:"data"`
+}
+
+// createPlaylistRequest é o corpo da criação de uma playlist
+type createPlaylistRequest struct {
+	Attributes struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"attributes"`
+	Relationships struct {
+		Tracks tracksRequest `json:"tracks"`
+	} `json:"relationships"`
+}
+
+// songIDs retorna os IDs das músicas do catálogo, o único tipo de faixa aceito
+func (t tracksRequest) songIDs() ([]string, error) {
+	ids := make([]string, 0, len(t.Data))
+	for _, track := range t.Data {
+		if track.Type != "songs" {
+			return nil, domain.InvalidParameter("", fmt.Sprintf("track type %q is not supported (expected songs)", track.Type))
+		}
+		if track.ID == "" {
+			return nil, domain.InvalidParameter("", "track id is required")
+		}
+		ids = append(ids, track.ID)
+	}
+	return ids, nil
+}
+
+// decodeBody decodifica o corpo JSON da requisição em v
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return domain.InvalidParameter("", "request body must be a JSON object")
+	}
+	return nil
+}
+
+// ListPlaylists processa a requisição das playlists da biblioteca
+func (h *LibraryHandler) ListPlaylists(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user := userFrom(ctx)
+	playlists, err := h.libraryService.ListPlaylists(ctx, user, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writePage(w, playlists, func(playlist *domain.Playlist) libraryPlaylist {
+		return newLibraryPlaylist(playlist, user.Storefront, false)
+	})
+}
+
+// CreatePlaylist processa a criação de uma playlist na biblioteca
+func (h *LibraryHandler) CreatePlaylist(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	var req createPlaylistRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	songIDs, err := req.Relationships.Tracks.songIDs()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user := userFrom(ctx)
+	playlist, err := h.libraryService.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{
+		Name:        req.Attributes.Name,
+		Description: req.Attributes.Description,
+		SongIDs:     songIDs,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, dataResponse[libraryPlaylist]{Data: []libraryPlaylist{newLibraryPlaylist(playlist, user.Storefront, true)}})
+}
+
+// GetPlaylist processa a requisição de uma playlist da biblioteca pelo ID
+func (h *LibraryHandler) GetPlaylist(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	user := userFrom(ctx)
+	playlist, err := h.libraryService.GetPlaylist(ctx, user, pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[libraryPlaylist]{Data: []libraryPlaylist{newLibraryPlaylist(playlist, user.Storefront, true)}})
+}
+
+// GetPlaylistTracks processa a requisição das faixas de uma playlist da
+// biblioteca, paginadas por limit e offset
+func (h *LibraryHandler) GetPlaylistTracks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user := userFrom(ctx)
+	tracks, err := h.libraryService.GetPlaylistTracks(ctx, user, pathParam(r, "id"), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writePage(w, tracks, func(track *domain.Track) playlistTrack {
+		return newPlaylistTrack(track, user.Storefront)
+	})
+}
+
+// AddPlaylistTracks processa a adição de faixas a uma playlist da biblioteca
+func (h *LibraryHandler) AddPlaylistTracks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	var req tracksRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	songIDs, err := req.songIDs()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if _, err := h.libraryService.AddPlaylistTracks(ctx, userFrom(ctx), pathParam(r, "id"), songIDs); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemovePlaylistTrack processa a remoção de uma faixa de uma playlist da biblioteca
+func (h *LibraryHandler) RemovePlaylistTrack(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	if err := h.libraryService.RemovePlaylistTrack(ctx, userFrom(ctx), pathParam(r, "id"), pathParam(r, "trackId")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddToLibrary processa a adição de recursos do catálogo à biblioteca, pelos
+// parâmetros ids[songs] e ids[albums]
+func (h *LibraryHandler) AddToLibrary(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	var params driving.AddToLibraryParameters
+	var err error
+	if q.has("ids[songs]") {
+		if params.SongIDs, err = q.list("ids[songs]", driving.MaxSongIDs); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	if q.has("ids[albums]") {
+		if params.AlbumIDs, err = q.list("ids[albums]", driving.MaxAlbumIDs); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	if err := h.libraryService.AddToLibrary(ctx, userFrom(ctx), params); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ListSongs processa a requisição das músicas da biblioteca
+func (h *LibraryHandler) ListSongs(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	songs, err := h.libraryService.ListSongs(ctx, userFrom(ctx), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writePage(w, songs, newLibrarySong)
+}
+
+// GetSong processa a requisição de uma música da biblioteca pelo ID
+func (h *LibraryHandler) GetSong(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	song, err := h.libraryService.GetSong(ctx, userFrom(ctx), pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[librarySong]{Data: []librarySong{newLibrarySong(song)}})
+}
+
+// GetSongCatalog processa a requisição do relacionamento catalog de uma música
+// da biblioteca, a música do catálogo de onde ela veio
+func (h *LibraryHandler) GetSongCatalog(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	song, err := h.libraryService.GetSong(ctx, userFrom(ctx), pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newLibrarySong(song).Relationships.Catalog)
+}
+
+// ListAlbums processa a requisição dos álbuns da biblioteca
+func (h *LibraryHandler) ListAlbums(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	albums, err := h.libraryService.ListAlbums(ctx, userFrom(ctx), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writePage(w, albums, newLibraryAlbum)
+}
+
+// GetAlbum processa a requisição de um álbum da biblioteca pelo ID
+func (h *LibraryHandler) GetAlbum(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	album, err := h.libraryService.GetAlbum(ctx, userFrom(ctx), pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[libraryAlbum]{Data: []libraryAlbum{newLibraryAlbum(album)}})
+}
+
+// GetAlbumCatalog processa a requisição do relacionamento catalog de um álbum
+// da biblioteca, o álbum do catálogo de onde ele veio
+func (h *LibraryHandler) GetAlbumCatalog(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	album, err := h.libraryService.GetAlbum(ctx, userFrom(ctx), pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newLibraryAlbum(album).Relationships.Catalog)
+}
+
+// ListArtists processa a requisição dos artistas da biblioteca
+func (h *LibraryHandler) ListArtists(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	page, err := h.options.query(r).page()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	artists, err := h.libraryService.ListArtists(ctx, userFrom(ctx), page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writePage(w, artists, newLibraryArtist)
+}
+
+// GetArtist processa a requisição de um artista da biblioteca pelo ID
+func (h *LibraryHandler) GetArtist(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	artist, err := h.libraryService.GetArtist(ctx, userFrom(ctx), pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[libraryArtist]{Data: []libraryArtist{newLibraryArtist(artist)}})
+}
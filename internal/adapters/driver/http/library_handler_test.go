@@ -0,0 +1,290 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"applemusic-api-simulator/internal/adapters/driven/fixture"
+	"applemusic-api-simulator/internal/adapters/driven/memory"
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// libraryCatalog é o catálogo das fixtures usadas nos testes da biblioteca
+const libraryCatalog = `{
+  "artists": [{"id": "test-artist", "name": "Test Artist"}],
+  "albums": [{"id": "test-album", "name": "Test Album", "artist": "test-artist"}],
+  "songs": [
+    {"id": "first-song", "name": "First Song", "album": "test-album", "trackNumber": 1, "durationInMillis": 2000},
+    {"id": "second-song", "name": "Second Song", "album": "test-album", "trackNumber": 2}
+  ]
+}`
+
+// newLibraryRouter cria um roteador com as rotas da biblioteca, do histórico e
+// das avaliações servindo um catálogo de fixtures e retorna também um Music-User-Token válido
+func newLibraryRouter(t *testing.T) (http.Handler, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(libraryCatalog), 0o600))
+	provider, err := fixture.NewFixtureAdapter(path)
+	require.NoError(t, err)
+
+	playlists := memory.NewPlaylistRepository()
+	userService := services.NewUserService()
+	historyService := services.NewHistoryService(provider, memory.NewPlayHistoryRepository(0), playlists, userService)
+	router := withAdmin(Router(Handlers{
+		Me:      NewMeHandler(userService, services.NewStorefrontService()),
+		Library: NewLibraryHandler(services.NewLibraryService(provider, playlists, memory.NewLibraryRepository())),
+		History: NewHistoryHandler(historyService),
+		Rating:  NewRatingHandler(services.NewRatingService(provider, playlists, memory.NewRatingRepository())),
+	}), NewAdminHandler(userService, historyService))
+	return router, issueUserToken(t, router, `{"userId":"alice"}`).Token
+}
+
+// serveUser executa uma requisição autenticada com o Music-User-Token informado
+func serveUser(router http.Handler, token, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(MusicUserTokenHeader, token)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestLibraryHandler_Playlists(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	rr := serveUser(router, token, "POST", "/v1/me/library/playlists", `{
+		"attributes": {"name": "Road Trip", "description": "Songs for the road"},
+		"relationships": {"tracks": {"data": [{"id": "first-song", "type": "songs"}]}}
+	}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var created dataResponse[libraryPlaylist]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&created))
+	require.Len(t, created.Data, 1)
+	playlist := created.Data[0]
+	assert.Equal(t, "library-playlists", playlist.Type)
+	assert.Equal(t, "/v1/me/library/playlists/"+playlist.ID, playlist.Href)
+	assert.Equal(t, "Road Trip", playlist.Attributes.Name)
+	assert.Equal(t, "Songs for the road", playlist.Attributes.Description.Standard)
+	assert.Equal(t, libraryPlayParams{ID: playlist.ID, Kind: "playlist", IsLibrary: true}, playlist.Attributes.PlayParams)
+	require.NotNil(t, playlist.Relationships)
+	if assert.Len(t, playlist.Relationships.Tracks.Data, 1) {
+		track := playlist.Relationships.Tracks.Data[0]
+		assert.Equal(t, "first-song", track.ID)
+		assert.Equal(t, "/v1/catalog/us/songs/first-song", track.Href)
+		assert.Equal(t, "First Song", track.Attributes.Name)
+	}
+
+	rr = serveUser(router, token, "POST", "/v1/me/library/playlists/"+playlist.ID+"/tracks",
+		`{"data": [{"id": "second-song", "type": "songs"}, {"id": "first-song", "type": "songs"}]}`)
+	require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+	rr = serveUser(router, token, "DELETE", "/v1/me/library/playlists/"+playlist.ID+"/tracks/first-song", "")
+	require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/playlists/"+playlist.ID, "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var fetched dataResponse[libraryPlaylist]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&fetched))
+	require.Len(t, fetched.Data, 1)
+	if assert.Len(t, fetched.Data[0].Relationships.Tracks.Data, 1) {
+		assert.Equal(t, "second-song", fetched.Data[0].Relationships.Tracks.Data[0].ID)
+	}
+
+	// O relacionamento tracks aponta para a rota paginada das faixas
+	tracksHref := fetched.Data[0].Relationships.Tracks.Href
+	assert.Equal(t, "/v1/me/library/playlists/"+playlist.ID+"/tracks", tracksHref)
+	rr = serveUser(router, token, "POST", tracksHref, `{"data": [{"id": "first-song", "type": "songs"}]}`)
+	require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+	rr = serveUser(router, token, "GET", tracksHref+"?limit=1", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var tracks driving.Page[playlistTrack]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&tracks))
+	assert.Equal(t, tracksHref, tracks.Href)
+	assert.Equal(t, tracksHref+"?offset=1&limit=1", tracks.Next)
+	if assert.Len(t, tracks.Data, 1) {
+		assert.Equal(t, "second-song", tracks.Data[0].ID)
+		assert.Equal(t, "/v1/catalog/us/songs/second-song", tracks.Data[0].Href)
+	}
+
+	rr = serveUser(router, token, "GET", tracks.Next, "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	tracks = driving.Page[playlistTrack]{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&tracks))
+	assert.Empty(t, tracks.Next)
+	if assert.Len(t, tracks.Data, 1) {
+		assert.Equal(t, "first-song", tracks.Data[0].ID)
+	}
+
+	rr = serveUser(router, token, "GET", tracksHref+"?offset=-1", "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = serveUser(router, token, "GET", "/v1/me/library/playlists/p.unknown/tracks", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/playlists", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list struct {
+		Href string            `json:"href"`
+		Data []libraryPlaylist `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	assert.Equal(t, "/v1/me/library/playlists", list.Href)
+	if assert.Len(t, list.Data, 1) {
+		assert.Equal(t, playlist.ID, list.Data[0].ID)
+		assert.Nil(t, list.Data[0].Relationships, "lists do not include the tracks")
+	}
+
+	// As playlists pertencem ao usuário do token
+	other := issueUserToken(t, router, `{"userId":"bob"}`).Token
+	rr = serveUser(router, other, "GET", "/v1/me/library/playlists/"+playlist.ID, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestLibraryHandler_PlaylistErrors(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	rr := serveUser(router, token, "POST", "/v1/me/library/playlists", `{"attributes": {"name": "Mine"}}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var created dataResponse[libraryPlaylist]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&created))
+	tracksPath := "/v1/me/library/playlists/" + created.Data[0].ID + "/tracks"
+
+	full := make([]string, domain.MaxTracksPerPlaylist)
+	for i := range full {
+		full[i] = `{"id": "first-song", "type": "songs"}`
+	}
+	rr = serveUser(router, token, "POST", tracksPath, fmt.Sprintf(`{"data": [%s]}`, strings.Join(full, ",")))
+	require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"Missing name", "POST", "/v1/me/library/playlists", `{"attributes": {}}`, http.StatusBadRequest},
+		{"Malformed body", "POST", "/v1/me/library/playlists", `{"attributes":`, http.StatusBadRequest},
+		{"Unknown song", "POST", "/v1/me/library/playlists", `{"attributes": {"name": "x"}, "relationships": {"tracks": {"data": [{"id": "unknown", "type": "songs"}]}}}`, http.StatusBadRequest},
+		{"Unsupported track type", "POST", tracksPath, `{"data": [{"id": "test-album", "type": "albums"}]}`, http.StatusBadRequest},
+		{"Playlist full", "POST", tracksPath, `{"data": [{"id": "second-song", "type": "songs"}]}`, http.StatusBadRequest},
+		{"Unknown playlist", "POST", "/v1/me/library/playlists/p.unknown/tracks", `{"data": [{"id": "first-song", "type": "songs"}]}`, http.StatusNotFound},
+		{"Track not in playlist", "DELETE", tracksPath + "/second-song", "", http.StatusNotFound},
+		{"Invalid limit", "GET", "/v1/me/library/playlists?limit=500", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serveUser(router, token, tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			var response errorResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			assert.Len(t, response.Errors, 1)
+		})
+	}
+
+	rr = serveUser(router, "", "GET", "/v1/me/library/playlists", "")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, "library routes require a Music-User-Token")
+}
+
+func TestLibraryHandler_AddToLibrary(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	rr := serveUser(router, token, "POST", "/v1/me/library?ids[songs]=first-song,second-song&ids[albums]=test-album", "")
+	require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/songs?limit=1", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var songs struct {
+		Next string        `json:"next"`
+		Data []librarySong `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&songs))
+	assert.Equal(t, "/v1/me/library/songs?offset=1&limit=1", songs.Next)
+	require.Len(t, songs.Data, 1)
+	song := songs.Data[0]
+	assert.Equal(t, "library-songs", song.Type)
+	assert.True(t, strings.HasPrefix(song.ID, "i."), song.ID)
+	assert.Equal(t, "First Song", song.Attributes.Name)
+	assert.Equal(t, libraryPlayParams{ID: song.ID, Kind: "song", IsLibrary: true, CatalogID: "first-song"}, song.Attributes.PlayParams)
+	if assert.Len(t, song.Relationships.Catalog.Data, 1) {
+		assert.Equal(t, "first-song", song.Relationships.Catalog.Data[0].ID)
+		assert.Equal(t, "/v1/catalog/us/songs/first-song", song.Relationships.Catalog.Data[0].Href)
+	}
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/songs/"+song.ID, "")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	// O href do relacionamento catalog é servido
+	rr = serveUser(router, token, "GET", song.Relationships.Catalog.Href, "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var songCatalog dataResponse[domain.Song]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&songCatalog))
+	if assert.Len(t, songCatalog.Data, 1) {
+		assert.Equal(t, "first-song", songCatalog.Data[0].ID)
+	}
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/albums", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var albums dataResponse[libraryAlbum]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&albums))
+	require.Len(t, albums.Data, 1)
+	assert.Equal(t, "library-albums", albums.Data[0].Type)
+	assert.True(t, strings.HasPrefix(albums.Data[0].ID, "l."), albums.Data[0].ID)
+	if assert.Len(t, albums.Data[0].Relationships.Catalog.Data, 1) {
+		assert.Equal(t, "test-album", albums.Data[0].Relationships.Catalog.Data[0].ID)
+	}
+
+	rr = serveUser(router, token, "GET", albums.Data[0].Relationships.Catalog.Href, "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var albumCatalog dataResponse[domain.Album]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&albumCatalog))
+	if assert.Len(t, albumCatalog.Data, 1) {
+		assert.Equal(t, "test-album", albumCatalog.Data[0].ID)
+	}
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/artists", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var artists dataResponse[libraryArtist]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&artists))
+	require.Len(t, artists.Data, 1, "every item is by the same artist")
+	assert.Equal(t, "library-artists", artists.Data[0].Type)
+	assert.Equal(t, "Test Artist", artists.Data[0].Attributes.Name)
+
+	rr = serveUser(router, token, "GET", "/v1/me/library/artists/"+artists.Data[0].ID, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"Missing ids", "POST", "/v1/me/library", http.StatusBadRequest},
+		{"Empty ids", "POST", "/v1/me/library?ids[songs]=", http.StatusBadRequest},
+		{"Unknown song", "POST", "/v1/me/library?ids[songs]=unknown", http.StatusBadRequest},
+		{"Unknown library song", "GET", "/v1/me/library/songs/i.unknown", http.StatusNotFound},
+		{"Unknown library album", "GET", "/v1/me/library/albums/l.unknown", http.StatusNotFound},
+		{"Catalog of unknown library song", "GET", "/v1/me/library/songs/i.unknown/catalog", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serveUser(router, token, tt.method, tt.path, "")
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// MusicUserTokenHeader é o header com o token do usuário nas rotas pessoais
+const MusicUserTokenHeader = "Music-User-Token"
+
+// MeHandler lida com as requisições pessoais do usuário, em /v1/me
+type MeHandler struct {
+	userService       driving.UserService
+	storefrontService driving.StorefrontService
+}
+
+// NewMeHandler cria uma nova instância do handler de rotas pessoais
+func NewMeHandler(userService driving.UserService, storefrontService driving.StorefrontService) *MeHandler {
+	return &MeHandler{
+		userService:       userService,
+		storefrontService: storefrontService,
+	}
+}
+
+type userContextKey struct{}
+
+// authenticate exige um Music-User-Token válido e disponibiliza o usuário no
+// contexto da requisição. Sem o header a resposta é 401; um token inválido,
+// revogado ou expirado recebe 403, como na Apple Music API.
+func (h *MeHandler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(MusicUserTokenHeader)
+		if token == "" {
+			writeError(w, domain.Unauthorized("a Music-User-Token header is required"))
+			return
+		}
+		user, err := h.userService.Authenticate(r.Context(), token)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, user)))
+	})
+}
+
+// userFrom retorna o usuário autenticado da requisição
+func userFrom(ctx context.Context) *domain.User {
+	user, _ := ctx.Value(userContextKey{}).(*domain.User)
+	return user
+}
+
+// GetStorefront processa a requisição do storefront do usuário
+func (h *MeHandler) GetStorefront(w http.ResponseWriter, r *http.Request) {
+	storefront, err := h.storefrontService.GetStorefront(userFrom(r.Context()).Storefront)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Storefront]{Data: []domain.Storefront{*storefront}})
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUserRouter cria um roteador com as rotas pessoais e de administração
+func newUserRouter() http.Handler {
+	userService := services.NewUserService()
+	return withAdmin(Router(Handlers{
+		Me: NewMeHandler(userService, services.NewStorefrontService()),
+	}), NewAdminHandler(userService, nil))
+}
+
+// withAdmin serve as rotas de administração junto das rotas da API, que em
+// produção ficam em endereços separados
+func withAdmin(api http.Handler, admin *AdminHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", AdminRouter(admin))
+	mux.Handle("/", api)
+	return mux
+}
+
+func TestRouter_DoesNotServeAdminRoutes(t *testing.T) {
+	router := Router(Handlers{Me: NewMeHandler(services.NewUserService(), services.NewStorefrontService())})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/user-tokens", strings.NewReader(`{"userId":"alice"}`)))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// issueUserToken emite um Music-User-Token pela rota de administração
+func issueUserToken(t *testing.T, router http.Handler, body string) userTokenResponse {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/user-tokens", strings.NewReader(body)))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var token userTokenResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&token))
+	return token
+}
+
+func TestAdminHandler_IssueUserToken(t *testing.T) {
+	router := newUserRouter()
+
+	token := issueUserToken(t, router, "")
+	assert.NotEmpty(t, token.Token)
+	assert.NotEmpty(t, token.UserID)
+	assert.Equal(t, services.DefaultUserStorefront, token.Storefront)
+	assert.Equal(t, services.DefaultUserTokenTTL, token.ExpiresAt.Sub(token.IssuedAt))
+
+	token = issueUserToken(t, router, `{"userId":"alice","storefront":"br","expiresIn":"1h"}`)
+	assert.Equal(t, "alice", token.UserID)
+	assert.Equal(t, "br", token.Storefront)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Malformed body", `{"userId":`},
+		{"Unknown storefront", `{"storefront":"zz"}`},
+		{"Invalid lifetime", `{"expiresIn":"tomorrow"}`},
+		{"Negative lifetime", `{"expiresIn":"-1h"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/user-tokens", strings.NewReader(tt.body)))
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestMeHandler_GetStorefront(t *testing.T) {
+	router := newUserRouter()
+	token := issueUserToken(t, router, `{"userId":"alice","storefront":"br"}`)
+	revoked := issueUserToken(t, router, `{"userId":"alice"}`)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/admin/user-tokens/"+revoked.Token, nil))
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/admin/user-tokens/"+revoked.Token, nil))
+	require.Equal(t, http.StatusNotFound, rr.Code, "revoking twice")
+
+	tests := []struct {
+		name           string
+		userToken      string
+		expectedStatus int
+	}{
+		{"Valid token", token.Token, http.StatusOK},
+		{"Missing token", "", http.StatusUnauthorized},
+		{"Unknown token", "not-a-token", http.StatusForbidden},
+		{"Revoked token", revoked.Token, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v1/me/storefront", nil)
+			if tt.userToken != "" {
+				req.Header.Set(MusicUserTokenHeader, tt.userToken)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dataResponse[domain.Storefront]
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				if assert.Len(t, response.Data, 1) {
+					// Um novo token sem storefront mantém o storefront do usuário
+					assert.Equal(t, "br", response.Data[0].ID)
+				}
+			} else {
+				var response errorResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				assert.Len(t, response.Errors, 1)
+			}
+		})
+	}
+}
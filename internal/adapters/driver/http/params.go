@@ -0,0 +1,227 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/gorilla/mux"
+)
+
+// ValidationMode define como os parâmetros inválidos da query string são tratados
+type ValidationMode int
+
+const (
+	// StrictValidation rejeita parâmetros inválidos com 400, como a Apple Music API
+	StrictValidation ValidationMode = iota
+	// LenientValidation corrige valores fora do intervalo, ignora tipos desconhecidos
+	// e usa o primeiro valor de parâmetros duplicados
+	LenientValidation
+)
+
+// ParseValidationMode converte "strict" ou "lenient" em um ValidationMode
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch strings.ToLower(s) {
+	case "", "strict":
+		return StrictValidation, nil
+	case "lenient":
+		return LenientValidation, nil
+	default:
+		return StrictValidation, fmt.Errorf("invalid validation mode %q", s)
+	}
+}
+
+// DefaultRequestTimeout é o tempo máximo padrão para atender uma requisição,
+// incluindo todas as chamadas ao provedor de música
+const DefaultRequestTimeout = 30 * time.Second
+
+// Option configura os handlers HTTP
+type Option func(*handlerOptions)
+
+type handlerOptions struct {
+	validation     ValidationMode
+	requestTimeout time.Duration
+}
+
+// WithValidationMode define o modo de validação dos parâmetros da query string
+func WithValidationMode(mode ValidationMode) Option {
+	return func(o *handlerOptions) {
+		o.validation = mode
+	}
+}
+
+// WithRequestTimeout define o prazo de cada requisição; zero remove o prazo
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *handlerOptions) {
+		o.requestTimeout = timeout
+	}
+}
+
+func newHandlerOptions(opts []Option) handlerOptions {
+	o := handlerOptions{requestTimeout: DefaultRequestTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// context retorna o contexto da requisição com o prazo configurado. O contexto é
+// cancelado também quando o cliente desconecta, interrompendo as chamadas ao provedor.
+func (o handlerOptions) context(r *http.Request) (context.Context, context.CancelFunc) {
+	if o.requestTimeout <= 0 {
+		return context.WithCancel(r.Context())
+	}
+	return context.WithTimeout(r.Context(), o.requestTimeout)
+}
+
+// query retorna os parâmetros da query string da requisição com o modo de validação configurado
+func (o handlerOptions) query(r *http.Request) queryParams {
+	return queryParams{values: r.URL.Query(), strict: o.validation == StrictValidation}
+}
+
+// queryParams obtém e valida os parâmetros da query string
+type queryParams struct {
+	values url.Values
+	strict bool
+}
+
+// has informa se o parâmetro foi enviado, mesmo que vazio
+func (q queryParams) has(name string) bool {
+	return q.values.Has(name)
+}
+
+// get retorna o valor de um parâmetro; no modo estrito, parâmetros duplicados são rejeitados
+func (q queryParams) get(name string) (string, error) {
+	values := q.values[name]
+	if len(values) > 1 && q.strict {
+		return "", domain.InvalidParameter(name, fmt.Sprintf("%s parameter must not be repeated", name))
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+	return values[0], nil
+}
+
+// int obtém um parâmetro inteiro entre min e max. No modo leniente, valores abaixo
+// de min resultam no padrão e valores acima de max são limitados a max.
+func (q queryParams) int(name string, def, min, max int) (int, error) {
+	s, err := q.get(name)
+	if err != nil || s == "" {
+		return def, err
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return def, domain.InvalidParameter(name, fmt.Sprintf("%s must be an integer", name))
+	}
+
+	switch {
+	case v < min && q.strict:
+		return def, domain.InvalidParameter(name, fmt.Sprintf("Value must be an integer greater than or equal to %d", min))
+	case v > max && q.strict:
+		return def, domain.InvalidParameter(name, fmt.Sprintf("Value must be an integer less than or equal to %d", max))
+	case v < min:
+		return def, nil
+	case v > max:
+		return max, nil
+	}
+	return v, nil
+}
+
+// list obtém uma lista separada por vírgulas de um parâmetro obrigatório,
+// removendo vazios e duplicados
+func (q queryParams) list(name string, max int) ([]string, error) {
+	s, err := q.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	seen := make(map[string]bool)
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+
+	if len(values) == 0 {
+		return nil, domain.InvalidParameter(name, fmt.Sprintf("%s parameter is required", name))
+	}
+	if len(values) > max {
+		return nil, domain.InvalidParameter(name, fmt.Sprintf("%s accepts at most %d values", name, max))
+	}
+	return values, nil
+}
+
+// page obtém os parâmetros limit e offset de um relacionamento
+func (q queryParams) page() (driving.PageParameters, error) {
+	return q.pageWithin(driving.DefaultPageLimit, driving.MaxPageLimit)
+}
+
+// pageWithin obtém os parâmetros limit e offset com o limite padrão e máximo informados
+func (q queryParams) pageWithin(defaultLimit, maxLimit int) (driving.PageParameters, error) {
+	limit, err := q.int("limit", defaultLimit, 1, maxLimit)
+	if err != nil {
+		return driving.PageParameters{}, err
+	}
+	offset, err := q.int("offset", 0, 0, math.MaxInt)
+	if err != nil {
+		return driving.PageParameters{}, err
+	}
+	return driving.PageParameters{Limit: limit, Offset: offset}, nil
+}
+
+// genre obtém o parâmetro genre, um ID de gênero do catálogo. No modo estrito,
+// IDs inexistentes são rejeitados; no leniente, ignorados.
+func (q queryParams) genre() (string, error) {
+	genre, err := q.get("genre")
+	if err != nil {
+		return "", err
+	}
+	genre = strings.TrimSpace(genre)
+	if _, found := domain.FindGenre(genre); genre != "" && !found {
+		if q.strict {
+			return "", domain.InvalidParameter("genre", fmt.Sprintf("genre %s not found", genre))
+		}
+		return "", nil
+	}
+	return genre, nil
+}
+
+// catalog obtém e valida o storefront da rota e o idioma do parâmetro l
+func (q queryParams) catalog(r *http.Request) (driving.CatalogParameters, error) {
+	storefront, found := domain.FindStorefront(pathParam(r, "storefront"))
+	if !found {
+		return driving.CatalogParameters{}, domain.NotFound(fmt.Sprintf("storefront %s not found", pathParam(r, "storefront")))
+	}
+
+	l, err := q.get("l")
+	if err != nil {
+		return driving.CatalogParameters{}, err
+	}
+	language, supported := storefront.SupportedLanguage(l)
+	if !supported {
+		return driving.CatalogParameters{}, domain.InvalidParameter("l", fmt.Sprintf("language %s is not supported by storefront %s", l, storefront.ID))
+	}
+
+	return driving.CatalogParameters{Storefront: storefront.ID, Language: language}, nil
+}
+
+// pathParam obtém um parâmetro de rota, tanto do http.ServeMux quanto do gorilla/mux
+func pathParam(r *http.Request, name string) string {
+	if v := r.PathValue(name); v != "" {
+		return v
+	}
+	return mux.Vars(r)[name]
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationModes(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		strictStatus    int
+		strictParameter string
+		lenientStatus   int
+		lenientPage     driving.PageParameters
+	}{
+		{"Search limit above maximum", "/v1/catalog/us/search?term=test&limit=26", http.StatusBadRequest, "limit", http.StatusOK, driving.PageParameters{}},
+		{"Search limit below minimum", "/v1/catalog/us/search?term=test&limit=0", http.StatusBadRequest, "limit", http.StatusOK, driving.PageParameters{}},
+		{"Search negative offset", "/v1/catalog/us/search?term=test&offset=-1", http.StatusBadRequest, "offset", http.StatusOK, driving.PageParameters{}},
+		{"Search unknown type", "/v1/catalog/us/search?term=test&types=songs,videos", http.StatusBadRequest, "types", http.StatusOK, driving.PageParameters{}},
+		{"Search empty types", "/v1/catalog/us/search?term=test&types=", http.StatusBadRequest, "types", http.StatusOK, driving.PageParameters{}},
+		{"Search duplicated term", "/v1/catalog/us/search?term=a&term=b", http.StatusBadRequest, "term", http.StatusOK, driving.PageParameters{}},
+		{"Relationship limit above maximum", "/v1/catalog/us/albums/album/tracks?limit=101", http.StatusBadRequest, "limit", http.StatusOK, driving.PageParameters{Limit: 100}},
+		{"Relationship negative offset", "/v1/catalog/us/albums/album/tracks?offset=-5", http.StatusBadRequest, "offset", http.StatusOK, driving.PageParameters{Limit: 25}},
+		{"Relationship duplicated limit", "/v1/catalog/us/albums/album/tracks?limit=5&limit=10", http.StatusBadRequest, "limit", http.StatusOK, driving.PageParameters{Limit: 5}},
+		{"Duplicated language", "/v1/catalog/us/albums/album/tracks?l=en-US&l=es-MX", http.StatusBadRequest, "l", http.StatusOK, driving.PageParameters{Limit: 25}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := &mockMusicProvider{album: &domain.Album{ID: "album", Type: "albums"}}
+			strict := newTestRouter(mockProvider)
+			lenient := Router(Handlers{
+				Search:  NewSearchHandler(mockProvider, WithValidationMode(LenientValidation)),
+				Catalog: NewCatalogHandler(mockProvider, WithValidationMode(LenientValidation)),
+			})
+
+			rr := httptest.NewRecorder()
+			strict.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.strictStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"parameter":"`+tt.strictParameter+`"`)
+
+			mockProvider.lastPage = driving.PageParameters{}
+			rr = httptest.NewRecorder()
+			lenient.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.lenientStatus, rr.Code)
+			assert.Equal(t, tt.lenientPage, mockProvider.lastPage)
+		})
+	}
+}
+
+func TestParseValidationMode(t *testing.T) {
+	mode, err := ParseValidationMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, StrictValidation, mode)
+
+	mode, err = ParseValidationMode("Lenient")
+	assert.NoError(t, err)
+	assert.Equal(t, LenientValidation, mode)
+
+	_, err = ParseValidationMode("loose")
+	assert.Error(t, err)
+}
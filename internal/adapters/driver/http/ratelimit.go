@@ -0,0 +1,195 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// Grupos de rotas com cotas próprias de requisições
+const (
+	RouteSearch      = "search"
+	RouteCatalog     = "catalog"
+	RouteStorefronts = "storefronts"
+	RouteMe          = "me"
+)
+
+// Routes lista os grupos de rotas que aceitam cotas próprias
+var Routes = []string{RouteSearch, RouteCatalog, RouteStorefronts, RouteMe}
+
+// sweepInterval é o intervalo mínimo entre as limpezas dos buckets ociosos
+const sweepInterval = time.Minute
+
+// Quota é a cota de requisições de um cliente em um grupo de rotas: Requests
+// requisições a cada Per, permitindo rajadas de até Requests requisições.
+// Uma cota zero não limita as requisições.
+type Quota struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseQuota converte uma cota no formato "<requisições>/<duração>", como
+// "100/1m" ou "5/s"
+func ParseQuota(s string) (Quota, error) {
+	requests, per, ok := strings.Cut(s, "/")
+	if !ok {
+		return Quota{}, fmt.Errorf("invalid quota %q (expected <requests>/<duration>, e.g. 100/1m)", s)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 0 {
+		return Quota{}, fmt.Errorf("invalid quota %q: requests must be a non-negative integer", s)
+	}
+	// Aceitar unidades sem número, como "5/s"
+	if per != "" && (per[0] < '0' || per[0] > '9') {
+		per = "1" + per
+	}
+	d, err := time.ParseDuration(per)
+	if err != nil || d <= 0 {
+		return Quota{}, fmt.Errorf("invalid quota %q: duration must be positive", s)
+	}
+	return Quota{Requests: n, Per: d}, nil
+}
+
+func (q Quota) unlimited() bool {
+	return q.Requests <= 0
+}
+
+// RateLimiter limita as requisições de cada cliente com um token bucket por grupo
+// de rotas. Os clientes são identificados pelo developer token verificado pela
+// autenticação ou, sem ela, pelo IP. Requisições acima da cota recebem 429 com
+// o header Retry-After, como a Apple Music API.
+type RateLimiter struct {
+	defaultQuota Quota
+	quotas       map[string]Quota
+	now          func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+// RateLimitOption configura o RateLimiter
+type RateLimitOption func(*RateLimiter)
+
+// WithDefaultQuota define a cota dos grupos de rotas sem cota própria
+func WithDefaultQuota(quota Quota) RateLimitOption {
+	return func(l *RateLimiter) {
+		l.defaultQuota = quota
+	}
+}
+
+// WithRouteQuota define a cota de um grupo de rotas (RouteSearch, RouteCatalog...)
+func WithRouteQuota(route string, quota Quota) RateLimitOption {
+	return func(l *RateLimiter) {
+		l.quotas[route] = quota
+	}
+}
+
+type bucketKey struct {
+	route  string
+	client string
+}
+
+// bucket guarda os tokens disponíveis de um cliente em um grupo de rotas
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter cria um limitador de requisições. Sem opções, nenhuma rota é limitada.
+func NewRateLimiter(opts ...RateLimitOption) *RateLimiter {
+	l := &RateLimiter{
+		quotas:  make(map[string]Quota),
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// limit aplica a cota do grupo de rotas ao handler
+func (l *RateLimiter) limit(route string, next http.Handler) http.Handler {
+	quota := l.quota(route)
+	if quota.unlimited() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wait, ok := l.allow(route, clientKey(r), quota); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, domain.RateLimited(fmt.Sprintf("rate limit of %d requests per %s exceeded", quota.Requests, quota.Per)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *RateLimiter) quota(route string) Quota {
+	if quota, ok := l.quotas[route]; ok {
+		return quota
+	}
+	return l.defaultQuota
+}
+
+// allow consome um token do bucket do cliente. Se não houver tokens, retorna
+// quanto tempo falta para o próximo.
+func (l *RateLimiter) allow(route, client string, quota Quota) (time.Duration, bool) {
+	now := l.now()
+	rate := float64(quota.Requests) / quota.Per.Seconds() // Tokens por segundo
+	capacity := float64(quota.Requests)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	key := bucketKey{route: route, client: client}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// sweep remove os buckets que já teriam se enchido novamente, pois equivalem a
+// um cliente novo. Deve ser chamado com l.mu travado.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.quota(key.route).Per {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// clientKey identifica o cliente pelo developer token verificado ou pelo IP. O
+// header Authorization não verificado é ignorado, para que um cliente não
+// escape da cota trocando de token.
+func clientKey(r *http.Request) string {
+	if token := developerTokenFrom(r.Context()); token != "" {
+		return "token:" + token
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuota(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Quota
+		wantErr  bool
+	}{
+		{"100/1m", Quota{Requests: 100, Per: time.Minute}, false},
+		{"5/s", Quota{Requests: 5, Per: time.Second}, false},
+		{"0/1h", Quota{Per: time.Hour}, false},
+		{"100", Quota{}, true},
+		{"abc/1m", Quota{}, true},
+		{"-1/1m", Quota{}, true},
+		{"10/0s", Quota{}, true},
+		{"10/forever", Quota{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			quota, err := ParseQuota(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, quota)
+		})
+	}
+}
+
+func newRateLimitedRouter(limiter *RateLimiter) http.Handler {
+	musicService := &mockMusicProvider{}
+	return Router(Handlers{
+		Search:     NewSearchHandler(musicService),
+		Catalog:    NewCatalogHandler(musicService),
+		Storefront: NewStorefrontHandler(services.NewStorefrontService()),
+	}, WithRateLimiter(limiter))
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(WithRouteQuota(RouteStorefronts, Quota{Requests: 2, Per: time.Minute}))
+	limiter.now = func() time.Time { return now }
+	router := newRateLimitedRouter(limiter)
+
+	get := func(path, token, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, get("/v1/storefronts", "token-a", "10.0.0.1:1234").Code)
+	}
+
+	rr := get("/v1/storefronts/us", "token-a", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "30", rr.Header().Get("Retry-After"), "a token is refilled every 30 seconds")
+	var response errorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, "429", response.Errors[0].Status)
+		assert.Equal(t, "42900", response.Errors[0].Code)
+		assert.Equal(t, "API Capacity Exceeded", response.Errors[0].Title)
+	}
+
+	// Sem autenticação o token não é verificado, então o cliente é identificado
+	// pelo IP e não escapa da cota trocando de token
+	assert.Equal(t, http.StatusTooManyRequests, get("/v1/storefronts", "token-b", "10.0.0.1:5678").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get("/v1/storefronts", "", "10.0.0.1:9999").Code)
+	assert.Equal(t, http.StatusOK, get("/v1/storefronts", "token-a", "10.0.0.2:1234").Code)
+
+	// Grupos de rotas sem cota não são limitados
+	for i := 0; i < 5; i++ {
+		assert.NotEqual(t, http.StatusTooManyRequests, get("/v1/catalog/us/songs/test", "token-a", "10.0.0.1:1234").Code)
+	}
+
+	// Os tokens são repostos com o tempo
+	now = now.Add(10 * time.Second)
+	rr = get("/v1/storefronts", "token-a", "10.0.0.1:1234")
+	assert.Equal(t, "20", rr.Header().Get("Retry-After"))
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, http.StatusOK, get("/v1/storefronts", "token-a", "10.0.0.1:1234").Code)
+}
+
+func TestRateLimiter_DeveloperTokens(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	now := time.Now()
+	auth := NewDeveloperTokenAuth(map[string]*ecdsa.PublicKey{"ABC123DEFG": &key.PublicKey})
+	auth.now = func() time.Time { return now }
+	limiter := NewRateLimiter(WithRouteQuota(RouteStorefronts, Quota{Requests: 1, Per: time.Minute}))
+	limiter.now = func() time.Time { return now }
+	router := Router(Handlers{Storefront: NewStorefrontHandler(services.NewStorefrontService())},
+		WithDeveloperTokenAuth(auth), WithRateLimiter(limiter))
+
+	token := func(iss string) string {
+		header := map[string]any{"alg": "ES256", "kid": "ABC123DEFG"}
+		return signToken(t, key, header, map[string]any{"iss": iss, "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()})
+	}
+	get := func(token string) int {
+		req := httptest.NewRequest("GET", "/v1/storefronts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// Com a autenticação habilitada, cada developer token verificado tem a sua
+	// própria cota, mesmo vindo do mesmo IP
+	tokenA, tokenB := token("TEAMID1234"), token("TEAMID5678")
+	assert.Equal(t, http.StatusOK, get(tokenA))
+	assert.Equal(t, http.StatusTooManyRequests, get(tokenA))
+	assert.Equal(t, http.StatusOK, get(tokenB))
+
+	// Tokens inválidos são rejeitados antes de consumir qualquer cota
+	assert.Equal(t, http.StatusUnauthorized, get("not-a-jwt"))
+	assert.Equal(t, http.StatusTooManyRequests, get(tokenB))
+}
+
+func TestRateLimiter_Quotas(t *testing.T) {
+	limiter := NewRateLimiter(
+		WithDefaultQuota(Quota{Requests: 1, Per: time.Hour}),
+		WithRouteQuota(RouteSearch, Quota{Requests: 3, Per: time.Hour}),
+		WithRouteQuota(RouteCatalog, Quota{}),
+	)
+	router := newRateLimitedRouter(limiter)
+
+	statuses := func(path string, n int) []int {
+		var codes []int
+		for i := 0; i < n; i++ {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+			codes = append(codes, rr.Code)
+		}
+		return codes
+	}
+
+	assert.Equal(t, []int{200, 429}, statuses("/v1/storefronts", 2), "default quota")
+	assert.Equal(t, []int{200, 200, 200, 429}, statuses("/v1/catalog/us/search?term=test", 4), "route quota")
+	assert.NotContains(t, statuses("/v1/catalog/us/songs/test", 3), 429, "zero quota is unlimited")
+}
+
+func TestRateLimiter_SweepsIdleBuckets(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(WithDefaultQuota(Quota{Requests: 1, Per: time.Second}))
+	limiter.now = func() time.Time { return now }
+
+	_, ok := limiter.allow(RouteSearch, "ip:10.0.0.1", limiter.quota(RouteSearch))
+	assert.True(t, ok)
+	now = now.Add(sweepInterval)
+	_, ok = limiter.allow(RouteSearch, "ip:10.0.0.2", limiter.quota(RouteSearch))
+	assert.True(t, ok)
+	assert.Len(t, limiter.buckets, 1)
+}
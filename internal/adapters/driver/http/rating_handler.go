@@ -0,0 +1,126 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// RatingHandler lida com as requisições das avaliações do usuário.
+// As rotas exigem o Music-User-Token verificado pelo MeHandler.
+type RatingHandler struct {
+	ratingService driving.RatingService
+	options       handlerOptions
+}
+
+// NewRatingHandler cria uma nova instância do handler de avaliações
+func NewRatingHandler(ratingService driving.RatingService, opts ...Option) *RatingHandler {
+	return &RatingHandler{
+		ratingService: ratingService,
+		options:       newHandlerOptions(opts),
+	}
+}
+
+// rating representa uma avaliação no formato da Apple Music API
+type rating struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	Href       string           `json:"href"`
+	Attributes ratingAttributes `json:"attributes"`
+}
+
+// ratingAttributes representa os atributos de uma avaliação
+type ratingAttributes struct {
+	Value domain.RatingValue `json:"value"`
+}
+
+// ratingRequest é o corpo da requisição que avalia um recurso
+type ratingRequest struct {
+	Type       string           `json:"type"`
+	Attributes ratingAttributes `json:"attributes"`
+}
+
+func newRating(r domain.Rating) rating {
+	return rating{
+		ID:         r.ResourceID,
+		Type:       "ratings",
+		Href:       fmt.Sprintf("/v1/me/ratings/%s/%s", r.ResourceType, r.ResourceID),
+		Attributes: ratingAttributes{Value: r.Value},
+	}
+}
+
+// GetRating processa a requisição da avaliação de um recurso
+func (h *RatingHandler) GetRating(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	result, err := h.ratingService.GetRating(ctx, userFrom(ctx), pathParam(r, "type"), pathParam(r, "id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[rating]{Data: []rating{newRating(*result)}})
+}
+
+// GetRatings processa a requisição das avaliações de vários recursos pelo parâmetro ids
+func (h *RatingHandler) GetRatings(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	ids, err := h.options.query(r).list("ids", driving.MaxRatingIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results, err := h.ratingService.GetRatings(ctx, userFrom(ctx), pathParam(r, "type"), ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ratings := make([]rating, len(results))
+	for i, result := range results {
+		ratings[i] = newRating(result)
+	}
+	writeJSON(w, http.StatusOK, dataResponse[rating]{Data: ratings})
+}
+
+// SetRating processa a avaliação de um recurso
+func (h *RatingHandler) SetRating(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	var req ratingRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Type != "" && req.Type != "rating" {
+		writeError(w, domain.InvalidParameter("", fmt.Sprintf("type %q is not supported (expected rating)", req.Type)))
+		return
+	}
+
+	result, err := h.ratingService.SetRating(ctx, userFrom(ctx), pathParam(r, "type"), pathParam(r, "id"), req.Attributes.Value)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[rating]{Data: []rating{newRating(*result)}})
+}
+
+// DeleteRating processa a remoção da avaliação de um recurso
+func (h *RatingHandler) DeleteRating(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	if err := h.ratingService.DeleteRating(ctx, userFrom(ctx), pathParam(r, "type"), pathParam(r, "id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingHandler(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	rr := serveUser(router, token, "PUT", "/v1/me/ratings/songs/first-song", `{"type": "rating", "attributes": {"value": 1}}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var body dataResponse[rating]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, []rating{{
+		ID:         "first-song",
+		Type:       "ratings",
+		Href:       "/v1/me/ratings/songs/first-song",
+		Attributes: ratingAttributes{Value: 1},
+	}}, body.Data)
+
+	// Uma nova avaliação substitui a anterior
+	rr = serveUser(router, token, "PUT", "/v1/me/ratings/songs/first-song", `{"attributes": {"value": -1}}`)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	rr = serveUser(router, token, "GET", "/v1/me/ratings/songs/first-song", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, -1, int(body.Data[0].Attributes.Value))
+
+	rr = serveUser(router, token, "GET", "/v1/me/ratings/songs?ids=second-song,first-song", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	if assert.Len(t, body.Data, 1) {
+		assert.Equal(t, "first-song", body.Data[0].ID)
+	}
+
+	rr = serveUser(router, token, "DELETE", "/v1/me/ratings/songs/first-song", "")
+	assert.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+	rr = serveUser(router, token, "GET", "/v1/me/ratings/songs/first-song", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+}
+
+func TestRatingHandler_Errors(t *testing.T) {
+	router, token := newLibraryRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		token  string
+		status int
+	}{
+		{"Invalid value", "PUT", "/v1/me/ratings/songs/first-song", `{"attributes": {"value": 2}}`, token, http.StatusBadRequest},
+		{"Missing value", "PUT", "/v1/me/ratings/songs/first-song", `{}`, token, http.StatusBadRequest},
+		{"Wrong body type", "PUT", "/v1/me/ratings/songs/first-song", `{"type": "songs", "attributes": {"value": 1}}`, token, http.StatusBadRequest},
+		{"Malformed body", "PUT", "/v1/me/ratings/songs/first-song", `{"attributes":`, token, http.StatusBadRequest},
+		{"Unknown song", "PUT", "/v1/me/ratings/songs/unknown", `{"attributes": {"value": 1}}`, token, http.StatusBadRequest},
+		{"Unknown library playlist", "PUT", "/v1/me/ratings/library-playlists/p.unknown", `{"attributes": {"value": 1}}`, token, http.StatusBadRequest},
+		{"Unsupported type", "PUT", "/v1/me/ratings/stations/ra.1", `{"attributes": {"value": 1}}`, token, http.StatusNotFound},
+		{"Not rated", "GET", "/v1/me/ratings/albums/test-album", "", token, http.StatusNotFound},
+		{"Delete not rated", "DELETE", "/v1/me/ratings/albums/test-album", "", token, http.StatusNotFound},
+		{"Missing ids", "GET", "/v1/me/ratings/songs", "", token, http.StatusBadRequest},
+		{"Missing user token", "GET", "/v1/me/ratings/songs/first-song", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serveUser(router, tt.token, tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
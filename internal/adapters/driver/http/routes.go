@@ -7,41 +7,174 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// RouterOption configura o roteador da aplicação
+type RouterOption func(*routerOptions)
+
+type routerOptions struct {
+	rateLimiter *RateLimiter
+	auth        *DeveloperTokenAuth
+}
+
+// WithRateLimiter aplica as cotas do limitador às rotas da API
+func WithRateLimiter(limiter *RateLimiter) RouterOption {
+	return func(o *routerOptions) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithDeveloperTokenAuth exige um developer token válido nas rotas da API
+func WithDeveloperTokenAuth(auth *DeveloperTokenAuth) RouterOption {
+	return func(o *routerOptions) {
+		o.auth = auth
+	}
+}
+
+// Handlers reúne os handlers das rotas da aplicação. As rotas de handlers nil
+// não são registradas; as rotas da biblioteca, do histórico e das avaliações
+// dependem também do MeHandler, que verifica o Music-User-Token.
+type Handlers struct {
+	Search     *SearchHandler
+	Catalog    *CatalogHandler
+	Chart      *ChartHandler
+	Genre      *GenreHandler
+	Storefront *StorefrontHandler
+	Me         *MeHandler
+	Library    *LibraryHandler
+	History    *HistoryHandler
+	Rating     *RatingHandler
+}
+
 // Router configura as rotas da aplicação
-func Router(searchHandler *SearchHandler) http.Handler {
+func Router(handlers Handlers, opts ...RouterOption) http.Handler {
+	var o routerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	mux := http.NewServeMux()
 
+	// A autenticação é verificada antes da cota, que é contada por developer token
+	handle := func(pattern, route string, handler http.Handler) {
+		if o.rateLimiter != nil {
+			handler = o.rateLimiter.limit(route, handler)
+		}
+		if o.auth != nil {
+			handler = o.auth.authenticate(handler)
+		}
+		mux.Handle(pattern, handler)
+	}
+
+	// Rotas inexistentes também respondem com erros no formato da Apple
+	mux.HandleFunc("/", notFound)
+
 	// Rota de busca
-	mux.HandleFunc("/v1/catalog/us/search", searchHandler.Search)
+	if h := handlers.Search; h != nil {
+		handle("GET /v1/catalog/{storefront}/search", RouteSearch, http.HandlerFunc(h.Search))
+	}
+
+	if h := handlers.Catalog; h != nil {
+		// Rotas de recursos do catálogo
+		handle("GET /v1/catalog/{storefront}/songs", RouteCatalog, http.HandlerFunc(h.GetSongs))
+		handle("GET /v1/catalog/{storefront}/albums", RouteCatalog, http.HandlerFunc(h.GetAlbums))
+		handle("GET /v1/catalog/{storefront}/artists", RouteCatalog, http.HandlerFunc(h.GetArtists))
+		handle("GET /v1/catalog/{storefront}/songs/{id}", RouteCatalog, http.HandlerFunc(h.GetSong))
+		handle("GET /v1/catalog/{storefront}/albums/{id}", RouteCatalog, http.HandlerFunc(h.GetAlbum))
+		handle("GET /v1/catalog/{storefront}/artists/{id}", RouteCatalog, http.HandlerFunc(h.GetArtist))
+
+		// Rotas de relacionamentos
+		handle("GET /v1/catalog/{storefront}/albums/{id}/tracks", RouteCatalog, http.HandlerFunc(h.GetAlbumTracks))
+		handle("GET /v1/catalog/{storefront}/artists/{id}/albums", RouteCatalog, http.HandlerFunc(h.GetArtistAlbums))
+	}
+
+	// Rota de rankings
+	if h := handlers.Chart; h != nil {
+		handle("GET /v1/catalog/{storefront}/charts", RouteCatalog, http.HandlerFunc(h.GetCharts))
+	}
+
+	// Rotas de gêneros
+	if h := handlers.Genre; h != nil {
+		handle("GET /v1/catalog/{storefront}/genres", RouteCatalog, http.HandlerFunc(h.GetGenres))
+		handle("GET /v1/catalog/{storefront}/genres/{id}", RouteCatalog, http.HandlerFunc(h.GetGenre))
+	}
+
+	// Rotas de storefronts
+	if h := handlers.Storefront; h != nil {
+		handle("GET /v1/storefronts", RouteStorefronts, http.HandlerFunc(h.ListStorefronts))
+		handle("GET /v1/storefronts/{id}", RouteStorefronts, http.HandlerFunc(h.GetStorefront))
+	}
+
+	// Rotas pessoais, que exigem também um Music-User-Token
+	if h := handlers.Me; h != nil {
+		handle("GET /v1/me/storefront", RouteMe, h.authenticate(http.HandlerFunc(h.GetStorefront)))
+	}
+
+	// Rotas da biblioteca do usuário
+	if me, h := handlers.Me, handlers.Library; me != nil && h != nil {
+		handle("POST /v1/me/library", RouteMe, me.authenticate(http.HandlerFunc(h.AddToLibrary)))
+		handle("GET /v1/me/library/songs", RouteMe, me.authenticate(http.HandlerFunc(h.ListSongs)))
+		handle("GET /v1/me/library/songs/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.GetSong)))
+		handle("GET /v1/me/library/songs/{id}/catalog", RouteMe, me.authenticate(http.HandlerFunc(h.GetSongCatalog)))
+		handle("GET /v1/me/library/albums", RouteMe, me.authenticate(http.HandlerFunc(h.ListAlbums)))
+		handle("GET /v1/me/library/albums/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.GetAlbum)))
+		handle("GET /v1/me/library/albums/{id}/catalog", RouteMe, me.authenticate(http.HandlerFunc(h.GetAlbumCatalog)))
+		handle("GET /v1/me/library/artists", RouteMe, me.authenticate(http.HandlerFunc(h.ListArtists)))
+		handle("GET /v1/me/library/artists/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.GetArtist)))
+		handle("GET /v1/me/library/playlists", RouteMe, me.authenticate(http.HandlerFunc(h.ListPlaylists)))
+		handle("POST /v1/me/library/playlists", RouteMe, me.authenticate(http.HandlerFunc(h.CreatePlaylist)))
+		handle("GET /v1/me/library/playlists/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.GetPlaylist)))
+		handle("GET /v1/me/library/playlists/{id}/tracks", RouteMe, me.authenticate(http.HandlerFunc(h.GetPlaylistTracks)))
+		handle("POST /v1/me/library/playlists/{id}/tracks", RouteMe, me.authenticate(http.HandlerFunc(h.AddPlaylistTracks)))
+		handle("DELETE /v1/me/library/playlists/{id}/tracks/{trackId}", RouteMe, me.authenticate(http.HandlerFunc(h.RemovePlaylistTrack)))
+	}
+
+	// Rotas do histórico de reprodução do usuário
+	if me, h := handlers.Me, handlers.History; me != nil && h != nil {
+		handle("GET /v1/me/recent/played", RouteMe, me.authenticate(http.HandlerFunc(h.RecentlyPlayed)))
+		handle("GET /v1/me/recent/played/tracks", RouteMe, me.authenticate(http.HandlerFunc(h.RecentlyPlayedTracks)))
+		handle("GET /v1/me/history/heavy-rotation", RouteMe, me.authenticate(http.HandlerFunc(h.HeavyRotation)))
+	}
+
+	// Rotas das avaliações do usuário
+	if me, h := handlers.Me, handlers.Rating; me != nil && h != nil {
+		handle("GET /v1/me/ratings/{type}", RouteMe, me.authenticate(http.HandlerFunc(h.GetRatings)))
+		handle("GET /v1/me/ratings/{type}/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.GetRating)))
+		handle("PUT /v1/me/ratings/{type}/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.SetRating)))
+		handle("DELETE /v1/me/ratings/{type}/{id}", RouteMe, me.authenticate(http.HandlerFunc(h.DeleteRating)))
+	}
 
 	return mux
 }
 
-// loggingMiddleware registra informações sobre as requisições
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// TODO: Implementar logging adequado
-		next.ServeHTTP(w, r)
-	})
+// AdminRouter configura as rotas de administração do simulador. Elas emitem
+// Music-User-Tokens e alteram o estado de qualquer usuário sem autenticação,
+// então devem ser servidas em um endereço separado da API e acessível apenas
+// localmente.
+func AdminRouter(h *AdminHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", notFound)
+	mux.HandleFunc("POST /admin/user-tokens", h.IssueUserToken)
+	mux.HandleFunc("DELETE /admin/user-tokens/{token}", h.RevokeUserToken)
+	mux.HandleFunc("POST /admin/users/{userId}/plays", h.RecordPlays)
+	return mux
 }
 
-// corsMiddleware configura os headers CORS
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+func SetupRoutes(router *mux.Router, musicService driving.MusicService, storefrontService driving.StorefrontService, opts ...Option) {
+	router.NotFoundHandler = http.HandlerFunc(notFound)
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+	searchHandler := NewSearchHandler(musicService, opts...)
+	router.HandleFunc("/v1/catalog/{storefront}/search", searchHandler.Search).Methods("GET")
 
-		next.ServeHTTP(w, r)
-	})
-}
+	catalogHandler := NewCatalogHandler(musicService, opts...)
+	router.HandleFunc("/v1/catalog/{storefront}/songs", catalogHandler.GetSongs).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/albums", catalogHandler.GetAlbums).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/artists", catalogHandler.GetArtists).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/songs/{id}", catalogHandler.GetSong).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/albums/{id}", catalogHandler.GetAlbum).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/artists/{id}", catalogHandler.GetArtist).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/albums/{id}/tracks", catalogHandler.GetAlbumTracks).Methods("GET")
+	router.HandleFunc("/v1/catalog/{storefront}/artists/{id}/albums", catalogHandler.GetArtistAlbums).Methods("GET")
 
-func SetupRoutes(router *mux.Router, musicService driving.MusicService) {
-	searchHandler := NewSearchHandler(musicService)
-	router.HandleFunc("/v1/catalog/us/search", searchHandler.Search).Methods("GET")
+	storefrontHandler := NewStorefrontHandler(storefrontService)
+	router.HandleFunc("/v1/storefronts", storefrontHandler.ListStorefronts).Methods("GET")
+	router.HandleFunc("/v1/storefronts/{id}", storefrontHandler.GetStorefront).Methods("GET")
 }
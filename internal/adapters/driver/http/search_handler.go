@@ -1,133 +1,115 @@
 package http
 
 import (
-	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
-	"strconv"
+	"net/url"
 	"strings"
 
 	"applemusic-api-simulator/internal/core/domain"
 	"applemusic-api-simulator/internal/core/ports/driving"
 )
 
+// Limites do parâmetro limit da busca
+const (
+	defaultSearchLimit = 5
+	maxSearchLimit     = 25
+)
+
 // SearchHandler lida com as requisições de busca
 type SearchHandler struct {
 	musicService driving.MusicService
+	options      handlerOptions
 }
 
 // NewSearchHandler cria uma nova instância do handler de busca
-func NewSearchHandler(musicService driving.MusicService) *SearchHandler {
+func NewSearchHandler(musicService driving.MusicService, opts ...Option) *SearchHandler {
 	return &SearchHandler{
 		musicService: musicService,
+		options:      newHandlerOptions(opts),
 	}
 }
 
 // Search processa a requisição de busca
 func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.options.context(r)
+	defer cancel()
+
+	q := h.options.query(r)
+	catalog, err := q.catalog(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	// Obter parâmetros da query string
-	term := r.URL.Query().Get("term")
+	term, err := q.get("term")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 	if term == "" {
-		http.Error(w, "term parameter is required", http.StatusBadRequest)
+		writeError(w, domain.InvalidParameter("term", "term parameter is required"))
 		return
 	}
 
 	// Obter e validar limit
-	limitStr := r.URL.Query().Get("limit")
-	limit := 5 // Default limit
-	if limitStr != "" {
-		var err error
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			http.Error(w, "invalid limit parameter", http.StatusBadRequest)
-			return
-		}
-		if limit < 1 {
-			limit = 5
-		} else if limit > 25 {
-			limit = 25
-		}
+	limit, err := q.int("limit", defaultSearchLimit, 1, maxSearchLimit)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
 	// Obter e validar offset
-	offsetStr := r.URL.Query().Get("offset")
-	offset := 0
-	if offsetStr != "" {
-		var err error
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil {
-			http.Error(w, "invalid offset parameter", http.StatusBadRequest)
-			return
-		}
-		if offset < 0 {
-			offset = 0
-		}
+	offset, err := q.int("offset", 0, 0, math.MaxInt)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
 	// Obter e validar types
-	typesStr := r.URL.Query().Get("types")
-	var types []driving.SearchResultType
-	if typesStr != "" {
-		typeStrs := strings.Split(typesStr, ",")
-		for _, t := range typeStrs {
-			switch strings.TrimSpace(t) {
-			case "artists":
-				types = append(types, driving.ArtistsType)
-			case "songs":
-				types = append(types, driving.SongsType)
-			case "albums":
-				types = append(types, driving.AlbumsType)
-			}
-		}
-	} else {
-		// Default to all types if none specified
-		types = []driving.SearchResultType{
-			driving.ArtistsType,
-			driving.SongsType,
-			driving.AlbumsType,
-		}
+	types, err := searchTypes(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Obter e validar o gênero que filtra os resultados
+	genre, err := q.genre()
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
 	// Construir parâmetros de busca
 	params := driving.SearchParameters{
-		Term:   term,
-		Limit:  limit,
-		Offset: offset,
-		Types:  types,
+		CatalogParameters: catalog,
+		Term:              term,
+		Genre:             genre,
+		Limit:             limit,
+		Offset:            offset,
+		Types:             types,
 	}
 
 	// Realizar busca
-	results, err := h.musicService.Search(params)
+	results, err := h.musicService.Search(ctx, params)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("error performing search: %v", err), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	// Construir resposta no formato da Apple Music API
-	response := struct {
-		Results map[string]interface{} `json:"results"`
-		Meta    struct {
-			Results struct {
-				Order []string `json:"order"`
-			} `json:"results"`
-		} `json:"meta"`
-	}{
+	response := searchResponse{
 		Results: make(map[string]interface{}),
-		Meta: struct {
-			Results struct {
-				Order []string `json:"order"`
-			} `json:"results"`
-		}{
-			Results: struct {
-				Order []string `json:"order"`
-			}{
-				Order: make([]string, 0, len(types)),
-			},
-		},
 	}
+	response.Meta.Results.Order = make([]string, 0, len(types))
 
-	// Adicionar apenas os tipos solicitados à resposta
+	// Adicionar apenas os tipos solicitados que não falharam à resposta
 	for _, t := range types {
+		if results.Failed(t) {
+			continue
+		}
 		switch t {
 		case driving.ArtistsType:
 			response.Results["artists"] = struct {
@@ -135,8 +117,8 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 				Next string          `json:"next"`
 				Data []domain.Artist `json:"data"`
 			}{
-				Href: fmt.Sprintf("/v1/catalog/us/search?term=%s&types=artists&limit=%d&offset=%d", term, limit, offset),
-				Next: fmt.Sprintf("/v1/catalog/us/search?term=%s&types=artists&limit=%d&offset=%d", term, limit, offset+limit),
+				Href: searchHref(params, "artists", offset),
+				Next: searchHref(params, "artists", offset+limit),
 				Data: results.Artists,
 			}
 			response.Meta.Results.Order = append(response.Meta.Results.Order, "artists")
@@ -147,8 +129,8 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 				Next string        `json:"next"`
 				Data []domain.Song `json:"data"`
 			}{
-				Href: fmt.Sprintf("/v1/catalog/us/search?term=%s&types=songs&limit=%d&offset=%d", term, limit, offset),
-				Next: fmt.Sprintf("/v1/catalog/us/search?term=%s&types=songs&limit=%d&offset=%d", term, limit, offset+limit),
+				Href: searchHref(params, "songs", offset),
+				Next: searchHref(params, "songs", offset+limit),
 				Data: results.Songs,
 			}
 			response.Meta.Results.Order = append(response.Meta.Results.Order, "songs")
@@ -159,18 +141,91 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 				Next string         `json:"next"`
 				Data []domain.Album `json:"data"`
 			}{
-				Href: fmt.Sprintf("/v1/catalog/us/search?term=%s&types=albums&limit=%d&offset=%d", term, limit, offset),
-				Next: fmt.Sprintf("/v1/catalog/us/search?term=%s&types=albums&limit=%d&offset=%d", term, limit, offset+limit),
+				Href: searchHref(params, "albums", offset),
+				Next: searchHref(params, "albums", offset+limit),
 				Data: results.Albums,
 			}
 			response.Meta.Results.Order = append(response.Meta.Results.Order, "albums")
 		}
 	}
 
+	// Informar os tipos cuja busca falhou
+	for _, failure := range results.Failures {
+		obj, _ := newErrorObject(failure.Err)
+		response.Meta.Errors = append(response.Meta.Errors, searchError{Type: string(failure.Type), errorObject: obj})
+	}
+
 	// Enviar resposta
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, fmt.Sprintf("error encoding response: %v", err), http.StatusInternalServerError)
-		return
+	writeJSON(w, http.StatusOK, response)
+}
+
+// searchResponse representa a resposta da busca no formato da Apple Music API
+type searchResponse struct {
+	Results map[string]interface{} `json:"results"`
+	Meta    struct {
+		Results struct {
+			Order []string `json:"order"`
+		} `json:"results"`
+		// Errors lista os tipos cuja busca falhou, quando os demais tiveram sucesso
+		Errors []searchError `json:"errors,omitempty"`
+	} `json:"meta"`
+}
+
+// searchError é um erro no formato da Apple Music API com o tipo de resultado afetado
+type searchError struct {
+	Type string `json:"type"`
+	errorObject
+}
+
+// searchTypes obtém os tipos do parâmetro types, usando todos os tipos se ele não
+// for informado. No modo estrito, tipos desconhecidos e listas vazias são rejeitados.
+func searchTypes(q queryParams) ([]driving.SearchResultType, error) {
+	typesStr, err := q.get("types")
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(typesStr) == "" {
+		if q.has("types") && q.strict {
+			return nil, domain.InvalidParameter("types", "types parameter must not be empty")
+		}
+		// Default to all types if none specified
+		return []driving.SearchResultType{
+			driving.ArtistsType,
+			driving.SongsType,
+			driving.AlbumsType,
+		}, nil
+	}
+
+	var types []driving.SearchResultType
+	seen := make(map[driving.SearchResultType]bool)
+	for _, t := range strings.Split(typesStr, ",") {
+		searchType := driving.SearchResultType(strings.TrimSpace(t))
+		switch searchType {
+		case driving.ArtistsType, driving.SongsType, driving.AlbumsType:
+			if !seen[searchType] {
+				seen[searchType] = true
+				types = append(types, searchType)
+			}
+		default:
+			if q.strict {
+				return nil, domain.InvalidParameter("types", fmt.Sprintf("%s is not a supported type", searchType))
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return nil, domain.InvalidParameter("types", "types must contain at least one of artists, songs or albums")
+	}
+	return types, nil
+}
+
+// searchHref monta o href de uma página de resultados da busca para um tipo
+func searchHref(params driving.SearchParameters, resultType string, offset int) string {
+	href := fmt.Sprintf("/v1/catalog/%s/search?term=%s&types=%s&limit=%d&offset=%d",
+		params.Storefront, url.QueryEscape(params.Term), resultType, params.Limit, offset)
+	if params.Genre != "" {
+		href += "&genre=" + url.QueryEscape(params.Genre)
 	}
+	return href
 }
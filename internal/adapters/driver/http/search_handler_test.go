@@ -4,7 +4,9 @@ import (
 	"applemusic-api-simulator/internal/core/domain"
 	"applemusic-api-simulator/internal/core/ports/driven"
 	"applemusic-api-simulator/internal/core/ports/driving"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -60,16 +62,159 @@ type AppleMusicArtist struct {
 
 // mockMusicProvider é um mock do MusicProvider para testes
 type mockMusicProvider struct {
-	searchResults *driven.ProviderSearchResults
-	searchError   error
+	searchResults  *driven.ProviderSearchResults
+	searchError    error
+	searchFailures []driving.SearchFailure
+	song           *domain.Song
+	album          *domain.Album
+	artist         *domain.Artist
+	tracks         []domain.Song
+	getError       error
+	lastPage       driving.PageParameters
+	lastCatalog    driving.CatalogParameters
+	lastSearch     driving.SearchParameters
 }
 
-func (m *mockMusicProvider) Search(params driving.SearchParameters) (*driving.SearchResults, error) {
-	return &driving.SearchResults{
+func (m *mockMusicProvider) Search(ctx context.Context, params driving.SearchParameters) (*driving.SearchResults, error) {
+	m.lastSearch = params
+	if m.searchError != nil {
+		return nil, m.searchError
+	}
+
+	results := &driving.SearchResults{
 		Artists: []domain.Artist{},
 		Songs:   []domain.Song{},
 		Albums:  []domain.Album{},
-	}, m.searchError
+	}
+	if m.searchResults != nil {
+		for _, track := range m.searchResults.Tracks {
+			results.Songs = append(results.Songs, domain.Song{
+				ID:   track.ID,
+				Type: "songs",
+				Attributes: domain.SongAttributes{
+					Name:             track.Title,
+					ArtistName:       track.Artist,
+					DurationInMillis: track.Duration,
+				},
+			})
+		}
+		results.Albums = append(results.Albums, m.searchResults.Albums...)
+		results.Artists = append(results.Artists, m.searchResults.Artists...)
+	}
+	results.Failures = m.searchFailures
+	return results, nil
+}
+
+func (m *mockMusicProvider) GetSong(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Song, error) {
+	m.lastCatalog = catalog
+	if m.getError != nil {
+		return nil, m.getError
+	}
+	if m.song == nil || m.song.ID != id {
+		return nil, domain.ErrNotFound
+	}
+	return m.song, nil
+}
+
+func (m *mockMusicProvider) GetAlbum(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Album, error) {
+	if m.getError != nil {
+		return nil, m.getError
+	}
+	if m.album == nil || m.album.ID != id {
+		return nil, domain.ErrNotFound
+	}
+	return m.album, nil
+}
+
+func (m *mockMusicProvider) GetArtist(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Artist, error) {
+	if m.getError != nil {
+		return nil, m.getError
+	}
+	if m.artist == nil || m.artist.ID != id {
+		return nil, domain.ErrNotFound
+	}
+	return m.artist, nil
+}
+
+func (m *mockMusicProvider) GetSongs(ctx context.Context, catalog driving.CatalogParameters, ids []string) ([]domain.Song, error) {
+	songs := []domain.Song{}
+	for _, id := range ids {
+		song, err := m.GetSong(ctx, catalog, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		songs = append(songs, *song)
+	}
+	return songs, nil
+}
+
+func (m *mockMusicProvider) GetAlbums(ctx context.Context, catalog driving.CatalogParameters, ids []string) ([]domain.Album, error) {
+	albums := []domain.Album{}
+	for _, id := range ids {
+		album, err := m.GetAlbum(ctx, catalog, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		albums = append(albums, *album)
+	}
+	return albums, nil
+}
+
+func (m *mockMusicProvider) GetArtists(ctx context.Context, catalog driving.CatalogParameters, ids []string) ([]domain.Artist, error) {
+	artists := []domain.Artist{}
+	for _, id := range ids {
+		artist, err := m.GetArtist(ctx, catalog, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		artists = append(artists, *artist)
+	}
+	return artists, nil
+}
+
+func (m *mockMusicProvider) GetSongsByISRC(ctx context.Context, catalog driving.CatalogParameters, isrcs []string) ([]domain.Song, error) {
+	songs := []domain.Song{}
+	for _, isrc := range isrcs {
+		if m.song != nil && m.song.Attributes.ISRC == isrc {
+			songs = append(songs, *m.song)
+		}
+	}
+	return songs, nil
+}
+
+func (m *mockMusicProvider) GetAlbumsByUPC(ctx context.Context, catalog driving.CatalogParameters, upcs []string) ([]domain.Album, error) {
+	albums := []domain.Album{}
+	for _, upc := range upcs {
+		if m.album != nil && m.album.Attributes.UPC == upc {
+			albums = append(albums, *m.album)
+		}
+	}
+	return albums, nil
+}
+
+func (m *mockMusicProvider) GetAlbumTracks(ctx context.Context, catalog driving.CatalogParameters, id string, page driving.PageParameters) (*driving.Page[domain.Song], error) {
+	m.lastPage = page
+	if _, err := m.GetAlbum(ctx, catalog, id); err != nil {
+		return nil, err
+	}
+	return &driving.Page[domain.Song]{Href: "/v1/catalog/us/albums/" + id + "/tracks", Data: m.tracks}, nil
+}
+
+func (m *mockMusicProvider) GetArtistAlbums(ctx context.Context, catalog driving.CatalogParameters, id string, page driving.PageParameters) (*driving.Page[domain.Album], error) {
+	m.lastPage = page
+	if _, err := m.GetArtist(ctx, catalog, id); err != nil {
+		return nil, err
+	}
+	return &driving.Page[domain.Album]{Href: "/v1/catalog/us/artists/" + id + "/albums", Data: []domain.Album{*m.album}}, nil
 }
 
 func TestSearchHandler_Handle(t *testing.T) {
@@ -150,7 +295,6 @@ func TestSearchHandler_Handle(t *testing.T) {
 									Name:             "Test Track",
 									ArtistName:       "Test Artist",
 									DurationInMillis: 180000,
-									Description:      "Test Description",
 								},
 							},
 						},
@@ -167,9 +311,8 @@ func TestSearchHandler_Handle(t *testing.T) {
 									ArtistName  string `json:"artistName"`
 									Description string `json:"description,omitempty"`
 								}{
-									Name:        "Test Album",
-									ArtistName:  "Test Artist",
-									Description: "Test Description",
+									Name:       "Test Album",
+									ArtistName: "Test Artist",
 								},
 							},
 						},
@@ -186,9 +329,7 @@ func TestSearchHandler_Handle(t *testing.T) {
 									Description string `json:"description,omitempty"`
 									Genre       string `json:"genre,omitempty"`
 								}{
-									Name:        "Test Artist",
-									Description: "Test Description",
-									Genre:       "Pop",
+									Name: "Test Artist",
 								},
 							},
 						},
@@ -229,6 +370,7 @@ func TestSearchHandler_Handle(t *testing.T) {
 
 			// Criar request
 			req := httptest.NewRequest("GET", "/v1/catalog/us/search", nil)
+			req.SetPathValue("storefront", "us")
 			q := req.URL.Query()
 			if tt.query != "" {
 				q.Add("term", tt.query)
@@ -264,3 +406,90 @@ func TestSearchHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchHandler_PartialResults(t *testing.T) {
+	mockProvider := &mockMusicProvider{
+		searchResults: &driven.ProviderSearchResults{
+			Tracks: []domain.Track{{ID: "1", Title: "Test Track", Artist: "Test Artist"}},
+		},
+		searchFailures: []driving.SearchFailure{
+			{Type: driving.AlbumsType, Err: domain.UpstreamError("error searching albums", assert.AnError)},
+		},
+	}
+
+	rr := httptest.NewRecorder()
+	newTestRouter(mockProvider).ServeHTTP(rr, httptest.NewRequest("GET", "/v1/catalog/us/search?term=test&types=songs,albums", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Results map[string]json.RawMessage `json:"results"`
+		Meta    struct {
+			Results struct {
+				Order []string `json:"order"`
+			} `json:"results"`
+			Errors []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+				Code   string `json:"code"`
+			} `json:"errors"`
+		} `json:"meta"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+
+	assert.Contains(t, response.Results, "songs")
+	assert.NotContains(t, response.Results, "albums")
+	assert.Equal(t, []string{"songs"}, response.Meta.Results.Order)
+	if assert.Len(t, response.Meta.Errors, 1) {
+		assert.Equal(t, "albums", response.Meta.Errors[0].Type)
+		assert.Equal(t, "502", response.Meta.Errors[0].Status)
+		assert.Equal(t, "50200", response.Meta.Errors[0].Code)
+	}
+}
+
+func TestSearchHandler_Genre(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mode           ValidationMode
+		expectedStatus int
+		expectedGenre  string
+	}{
+		{"Genre", "genre=21", StrictValidation, http.StatusOK, "21"},
+		{"No genre", "", StrictValidation, http.StatusOK, ""},
+		{"Unknown genre", "genre=Rock", StrictValidation, http.StatusBadRequest, ""},
+		{"Unknown genre in lenient mode", "genre=Rock", LenientValidation, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := &mockMusicProvider{}
+			router := Router(Handlers{Search: NewSearchHandler(mockProvider, WithValidationMode(tt.mode))})
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/catalog/us/search?term=test&types=songs&"+tt.query, nil))
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.expectedGenre, mockProvider.lastSearch.Genre)
+
+			var response struct {
+				Results struct {
+					Songs struct {
+						Href string `json:"href"`
+					} `json:"songs"`
+				} `json:"results"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("error decoding response: %v", err)
+			}
+			if tt.expectedGenre != "" {
+				assert.Contains(t, response.Results.Songs.Href, "&genre="+tt.expectedGenre)
+			} else {
+				assert.NotContains(t, response.Results.Songs.Href, "genre=")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// StorefrontHandler lida com as requisições de storefronts
+type StorefrontHandler struct {
+	storefrontService driving.StorefrontService
+}
+
+// NewStorefrontHandler cria uma nova instância do handler de storefronts
+func NewStorefrontHandler(storefrontService driving.StorefrontService) *StorefrontHandler {
+	return &StorefrontHandler{
+		storefrontService: storefrontService,
+	}
+}
+
+// ListStorefronts processa a requisição de todos os storefronts
+func (h *StorefrontHandler) ListStorefronts(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, dataResponse[domain.Storefront]{Data: h.storefrontService.ListStorefronts()})
+}
+
+// GetStorefront processa a requisição de um storefront pelo ID
+func (h *StorefrontHandler) GetStorefront(w http.ResponseWriter, r *http.Request) {
+	id := pathParam(r, "id")
+
+	storefront, err := h.storefrontService.GetStorefront(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse[domain.Storefront]{Data: []domain.Storefront{*storefront}})
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+	"applemusic-api-simulator/internal/core/services"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorefrontHandler(t *testing.T) {
+	router := newTestRouter(&mockMusicProvider{})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedIDs    []string
+	}{
+		{"Single storefront", "/v1/storefronts/br", http.StatusOK, []string{"br"}},
+		{"Unknown storefront", "/v1/storefronts/zz", http.StatusNotFound, nil},
+		{"Unknown catalog storefront", "/v1/catalog/zz/search?term=test", http.StatusNotFound, nil},
+		{"Unknown catalog storefront on lookup", "/v1/catalog/zz/songs/test", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+
+			if tt.expectedIDs != nil {
+				var response dataResponse[domain.Storefront]
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("error decoding response: %v", err)
+				}
+				var ids []string
+				for _, sf := range response.Data {
+					ids = append(ids, sf.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+				assert.Equal(t, "storefronts", response.Data[0].Type)
+				assert.Equal(t, "pt-BR", response.Data[0].Attributes.DefaultLanguageTag)
+			}
+		})
+	}
+}
+
+func TestStorefrontHandler_List(t *testing.T) {
+	router := newTestRouter(&mockMusicProvider{})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/storefronts", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response dataResponse[domain.Storefront]
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	assert.Len(t, response.Data, len(domain.Storefronts()))
+}
+
+func TestCatalogHandler_Language(t *testing.T) {
+	mockProvider := &mockMusicProvider{song: &domain.Song{ID: "test", Type: "songs"}}
+	router := newTestRouter(mockProvider)
+
+	tests := []struct {
+		name             string
+		path             string
+		expectedStatus   int
+		expectedLanguage string
+	}{
+		{"Storefront default language", "/v1/catalog/br/songs/test", http.StatusOK, "pt-BR"},
+		{"Supported language", "/v1/catalog/br/songs/test?l=en-GB", http.StatusOK, "en-GB"},
+		{"Language tag is case insensitive", "/v1/catalog/us/songs/test?l=es-mx", http.StatusOK, "es-MX"},
+		{"Unsupported language", "/v1/catalog/br/songs/test?l=ja", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider.lastCatalog = driving.CatalogParameters{}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d; got %d", tt.expectedStatus, rr.Code)
+			}
+			assert.Equal(t, tt.expectedLanguage, mockProvider.lastCatalog.Language)
+		})
+	}
+}
+
+func TestSetupRoutes(t *testing.T) {
+	router := mux.NewRouter()
+	SetupRoutes(router, &mockMusicProvider{}, services.NewStorefrontService())
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{"Storefront", "/v1/storefronts/br", http.StatusOK},
+		{"Unknown storefront", "/v1/storefronts/zz", http.StatusNotFound},
+		{"Unknown catalog storefront", "/v1/catalog/zz/songs/test", http.StatusNotFound},
+		{"Unknown route", "/v1/unknown", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+
+	// Os parâmetros de rota do gorilla/mux chegam aos handlers
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/storefronts/br", nil))
+	var response dataResponse[domain.Storefront]
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if assert.Len(t, response.Data, 1) {
+		assert.Equal(t, "br", response.Data[0].ID)
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+)
+
+// Prefixo ISRC usado pelo simulador (país + código do registrante)
+const isrcPrefix = "USAMS"
+
+var isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
+// ISRCFor gera um ISRC estável para a música com o ID informado.
+// O formato segue a ISO 3901: país (2), registrante (3), ano (2) e designação (5).
+func ISRCFor(songID string) string {
+	h := hash(songID)
+	return fmt.Sprintf("%s%02d%05d", isrcPrefix, h%100, (h/100)%100000)
+}
+
+// ValidISRC informa se o código está no formato ISRC, sem hífens
+func ValidISRC(isrc string) bool {
+	return isrcPattern.MatchString(isrc)
+}
+
+// UPCFor gera um UPC-A estável, com dígito verificador, para o álbum com o ID informado
+func UPCFor(albumID string) string {
+	digits := fmt.Sprintf("%011d", hash(albumID)%100000000000)
+	return digits + string(rune('0'+upcCheckDigit(digits)))
+}
+
+// ValidUPC informa se o código é um UPC-A de 12 dígitos com dígito verificador válido
+func ValidUPC(upc string) bool {
+	if len(upc) != 12 {
+		return false
+	}
+	for _, c := range upc {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return int(upc[11]-'0') == upcCheckDigit(upc[:11])
+}
+
+// upcCheckDigit calcula o dígito verificador dos 11 primeiros dígitos de um UPC-A
+func upcCheckDigit(digits string) int {
+	sum := 0
+	for i, c := range digits {
+		d := int(c - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
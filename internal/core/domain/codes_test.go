@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISRCFor(t *testing.T) {
+	isrc := ISRCFor("queen-bohemian-rhapsody")
+
+	assert.True(t, ValidISRC(isrc), isrc)
+	assert.Equal(t, isrc, ISRCFor("queen-bohemian-rhapsody"))
+	assert.NotEqual(t, isrc, ISRCFor("queen-love-of-my-life"))
+}
+
+func TestUPCFor(t *testing.T) {
+	upc := UPCFor("queen-a-night-at-the-opera")
+
+	assert.True(t, ValidUPC(upc), upc)
+	assert.Equal(t, upc, UPCFor("queen-a-night-at-the-opera"))
+	assert.NotEqual(t, upc, UPCFor("queen-news-of-the-world"))
+}
+
+func TestValidUPC(t *testing.T) {
+	assert.True(t, ValidUPC("036000291452"))
+	assert.False(t, ValidUPC("036000291453"))
+	assert.False(t, ValidUPC("03600029145"))
+	assert.False(t, ValidUPC("03600029145A"))
+}
+
+func TestValidISRC(t *testing.T) {
+	assert.True(t, ValidISRC("GBUM71029604"))
+	assert.False(t, ValidISRC("GB-UM7-10-29604"))
+	assert.False(t, ValidISRC("GBUM7102960"))
+}
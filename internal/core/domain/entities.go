@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Track representa uma faixa musical
 type Track struct {
@@ -71,10 +75,16 @@ type SongAttributes struct {
 
 // Album represents an album in the Apple Music catalog
 type Album struct {
-	ID         string          `json:"id"`
-	Type       string          `json:"type"`
-	Href       string          `json:"href"`
-	Attributes AlbumAttributes `json:"attributes"`
+	ID            string              `json:"id"`
+	Type          string              `json:"type"`
+	Href          string              `json:"href"`
+	Attributes    AlbumAttributes     `json:"attributes"`
+	Relationships *AlbumRelationships `json:"relationships,omitempty"`
+}
+
+// AlbumRelationships represents the relationships of an album
+type AlbumRelationships struct {
+	Tracks SongsRelationship `json:"tracks"`
 }
 
 // AlbumAttributes represents the attributes of an album
@@ -100,11 +110,11 @@ type AlbumAttributes struct {
 
 // Artist represents an artist in the Apple Music catalog
 type Artist struct {
-	ID            string              `json:"id"`
-	Type          string              `json:"type"`
-	Href          string              `json:"href"`
-	Attributes    ArtistAttributes    `json:"attributes"`
-	Relationships ArtistRelationships `json:"relationships,omitempty"`
+	ID            string               `json:"id"`
+	Type          string               `json:"type"`
+	Href          string               `json:"href"`
+	Attributes    ArtistAttributes     `json:"attributes"`
+	Relationships *ArtistRelationships `json:"relationships,omitempty"`
 }
 
 // ArtistAttributes represents the attributes of an artist
@@ -117,14 +127,28 @@ type ArtistAttributes struct {
 
 // ArtistRelationships represents the relationships of an artist
 type ArtistRelationships struct {
-	Albums struct {
-		Href string `json:"href"`
-		Data []struct {
-			ID   string `json:"id"`
-			Type string `json:"type"`
-			Href string `json:"href"`
-		} `json:"data"`
-	} `json:"albums"`
+	Albums AlbumsRelationship `json:"albums"`
+}
+
+// ResourceRef represents a reference to another resource inside a relationship
+type ResourceRef struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Href string `json:"href"`
+}
+
+// SongsRelationship represents a paginated relationship to songs
+type SongsRelationship struct {
+	Href string `json:"href"`
+	Next string `json:"next,omitempty"`
+	Data []Song `json:"data"`
+}
+
+// AlbumsRelationship represents a paginated relationship to albums
+type AlbumsRelationship struct {
+	Href string        `json:"href"`
+	Next string        `json:"next,omitempty"`
+	Data []ResourceRef `json:"data"`
 }
 
 // SearchResults represents the search results in Apple Music API format
@@ -155,20 +179,30 @@ type SearchResults struct {
 
 // Playlist representa uma playlist
 type Playlist struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description,omitempty"`
-	CoverURL    string  `json:"coverUrl,omitempty"`
-	Tracks      []Track `json:"tracks,omitempty"`
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	CoverURL         string    `json:"coverUrl,omitempty"`
+	Tracks           []Track   `json:"tracks,omitempty"`
+	DateAdded        time.Time `json:"dateAdded"`
+	LastModifiedDate time.Time `json:"lastModifiedDate"`
 }
 
 // MaxTracksPerPlaylist define o número máximo de faixas por playlist
 const MaxTracksPerPlaylist = 100
 
-// AddTrack adiciona uma faixa à playlist, respeitando as regras de negócio
+// ErrPlaylistFull indica que a playlist já tem MaxTracksPerPlaylist faixas
+var ErrPlaylistFull = errors.New("playlist has reached the maximum number of tracks")
+
+// AddTrack adiciona uma faixa à playlist, respeitando as regras de negócio.
+// Uma playlist cheia resulta em um erro KindInvalidParameter compatível com ErrPlaylistFull.
 func (p *Playlist) AddTrack(track Track) error {
 	if len(p.Tracks) >= MaxTracksPerPlaylist {
-		return errors.New("playlist has reached the maximum number of tracks")
+		return &Error{
+			Kind:   KindInvalidParameter,
+			Detail: fmt.Sprintf("a playlist can have at most %d tracks", MaxTracksPerPlaylist),
+			Err:    ErrPlaylistFull,
+		}
 	}
 
 	p.Tracks = append(p.Tracks, track)
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaylist_AddTrack(t *testing.T) {
+	var playlist Playlist
+	for i := 0; i < MaxTracksPerPlaylist; i++ {
+		assert.NoError(t, playlist.AddTrack(Track{ID: fmt.Sprintf("track-%d", i)}))
+	}
+
+	err := playlist.AddTrack(Track{ID: "one-too-many"})
+	assert.True(t, errors.Is(err, ErrPlaylistFull))
+	assert.Equal(t, KindInvalidParameter, KindOf(err))
+	assert.Len(t, playlist.Tracks, MaxTracksPerPlaylist)
+}
+
+func TestPlaylist_RemoveTrack(t *testing.T) {
+	playlist := Playlist{Tracks: []Track{{ID: "a"}, {ID: "b"}, {ID: "a"}}}
+
+	assert.True(t, playlist.RemoveTrack("a"))
+	assert.Equal(t, []Track{{ID: "b"}}, playlist.Tracks)
+	assert.False(t, playlist.RemoveTrack("unknown"))
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound indica que o recurso solicitado não existe no catálogo
+var ErrNotFound = errors.New("resource not found")
+
+// ErrorKind classifica os erros retornados pelos serviços e provedores
+type ErrorKind int
+
+const (
+	// KindInternal é um erro inesperado, sem classificação
+	KindInternal ErrorKind = iota
+	// KindInvalidParameter indica um parâmetro da requisição inválido
+	KindInvalidParameter
+	// KindNotFound indica que o recurso solicitado não existe
+	KindNotFound
+	// KindUpstream indica uma falha no provedor de dados externo
+	KindUpstream
+	// KindRateLimited indica que o limite de requisições foi excedido
+	KindRateLimited
+	// KindTimeout indica que o prazo da requisição expirou antes da resposta do provedor
+	KindTimeout
+	// KindUnauthorized indica que as credenciais da requisição estão ausentes ou inválidas
+	KindUnauthorized
+	// KindForbidden indica que as credenciais são válidas, mas não permitem a requisição
+	KindForbidden
+)
+
+// Error é um erro do domínio com uma classificação e, opcionalmente,
+// o parâmetro da requisição que o causou
+type Error struct {
+	Kind      ErrorKind
+	Parameter string
+	Detail    string
+	Err       error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Detail, e.Err)
+	}
+	return e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// InvalidParameter cria um erro para um parâmetro inválido da requisição
+func InvalidParameter(parameter, detail string) error {
+	return &Error{Kind: KindInvalidParameter, Parameter: parameter, Detail: detail}
+}
+
+// NotFound cria um erro de recurso inexistente, compatível com ErrNotFound
+func NotFound(detail string) error {
+	return &Error{Kind: KindNotFound, Detail: detail, Err: ErrNotFound}
+}
+
+// UpstreamError cria um erro para uma falha no provedor de dados externo
+func UpstreamError(detail string, err error) error {
+	return &Error{Kind: KindUpstream, Detail: detail, Err: err}
+}
+
+// RateLimited cria um erro para um limite de requisições excedido
+func RateLimited(detail string) error {
+	return &Error{Kind: KindRateLimited, Detail: detail}
+}
+
+// Timeout cria um erro para um prazo expirado ao consultar o provedor de dados externo
+func Timeout(detail string, err error) error {
+	return &Error{Kind: KindTimeout, Detail: detail, Err: err}
+}
+
+// Unauthorized cria um erro para credenciais ausentes ou inválidas
+func Unauthorized(detail string) error {
+	return &Error{Kind: KindUnauthorized, Detail: detail}
+}
+
+// Forbidden cria um erro para credenciais que não permitem a requisição
+func Forbidden(detail string) error {
+	return &Error{Kind: KindForbidden, Detail: detail}
+}
+
+// KindOf retorna a classificação de um erro, considerando toda a cadeia de erros
+func KindOf(err error) ErrorKind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	if errors.Is(err, ErrNotFound) {
+		return KindNotFound
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return KindTimeout
+	}
+	return KindInternal
+}
+
+// ParameterOf retorna o parâmetro da requisição que causou o erro, se houver
+func ParameterOf(err error) string {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Parameter
+	}
+	return ""
+}
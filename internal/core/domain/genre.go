@@ -0,0 +1,181 @@
+package domain
+
+import "strings"
+
+// MusicGenreID é o ID do gênero raiz "Music", pai dos gêneros principais
+const MusicGenreID = "34"
+
+// Genre represents a genre in the Apple Music catalog
+type Genre struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Href       string          `json:"href"`
+	Attributes GenreAttributes `json:"attributes"`
+}
+
+// GenreAttributes represents the attributes of a genre
+type GenreAttributes struct {
+	Name       string `json:"name"`
+	ParentID   string `json:"parentId,omitempty"`
+	ParentName string `json:"parentName,omitempty"`
+}
+
+// genres é a árvore de gêneros do catálogo, com os IDs da Apple Music. Cada gênero
+// principal é seguido dos seus subgêneros.
+var genres = withParentNames([]Genre{
+	newGenre(MusicGenreID, "Music", ""),
+	newGenre("20", "Alternative", MusicGenreID),
+	newGenre("1001", "College Rock", "20"),
+	newGenre("1002", "Goth Rock", "20"),
+	newGenre("1003", "Grunge", "20"),
+	newGenre("1004", "Indie Rock", "20"),
+	newGenre("1005", "New Wave", "20"),
+	newGenre("1006", "Punk", "20"),
+	newGenre("2", "Blues", MusicGenreID),
+	newGenre("4", "Children's Music", MusicGenreID),
+	newGenre("22", "Christian & Gospel", MusicGenreID),
+	newGenre("5", "Classical", MusicGenreID),
+	newGenre("3", "Comedy", MusicGenreID),
+	newGenre("6", "Country", MusicGenreID),
+	newGenre("17", "Dance", MusicGenreID),
+	newGenre("1044", "Breakbeat", "17"),
+	newGenre("1046", "Garage", "17"),
+	newGenre("1048", "House", "17"),
+	newGenre("1049", "Jungle/Drum'n'bass", "17"),
+	newGenre("1050", "Techno", "17"),
+	newGenre("1051", "Trance", "17"),
+	newGenre("7", "Electronic", MusicGenreID),
+	newGenre("1056", "Ambient", "7"),
+	newGenre("1057", "Downtempo", "7"),
+	newGenre("1058", "Electronica", "7"),
+	newGenre("1060", "IDM/Experimental", "7"),
+	newGenre("1061", "Industrial", "7"),
+	newGenre("18", "Hip-Hop/Rap", MusicGenreID),
+	newGenre("1068", "Alternative Rap", "18"),
+	newGenre("1070", "East Coast Rap", "18"),
+	newGenre("1071", "Gangsta Rap", "18"),
+	newGenre("1075", "Old School Rap", "18"),
+	newGenre("1077", "Underground Rap", "18"),
+	newGenre("1078", "West Coast Rap", "18"),
+	newGenre("27", "J-Pop", MusicGenreID),
+	newGenre("11", "Jazz", MusicGenreID),
+	newGenre("51", "K-Pop", MusicGenreID),
+	newGenre("12", "Latin", MusicGenreID),
+	newGenre("1153", "Metal", MusicGenreID),
+	newGenre("14", "Pop", MusicGenreID),
+	newGenre("1131", "Adult Contemporary", "14"),
+	newGenre("1132", "Britpop", "14"),
+	newGenre("1133", "Pop/Rock", "14"),
+	newGenre("1134", "Soft Rock", "14"),
+	newGenre("1135", "Teen Pop", "14"),
+	newGenre("15", "R&B/Soul", MusicGenreID),
+	newGenre("1136", "Contemporary R&B", "15"),
+	newGenre("1137", "Disco", "15"),
+	newGenre("1138", "Doo Wop", "15"),
+	newGenre("1139", "Funk", "15"),
+	newGenre("1140", "Motown", "15"),
+	newGenre("1141", "Neo-Soul", "15"),
+	newGenre("1143", "Soul", "15"),
+	newGenre("24", "Reggae", MusicGenreID),
+	newGenre("21", "Rock", MusicGenreID),
+	newGenre("1147", "Blues-Rock", "21"),
+	newGenre("1150", "Glam Rock", "21"),
+	newGenre("1152", "Hard Rock", "21"),
+	newGenre("1155", "Prog-Rock/Art Rock", "21"),
+	newGenre("1156", "Psychedelic", "21"),
+	newGenre("1157", "Rock & Roll", "21"),
+	newGenre("1161", "Southern Rock", "21"),
+	newGenre("1162", "Surf", "21"),
+	newGenre("10", "Singer/Songwriter", MusicGenreID),
+	newGenre("1062", "Alternative Folk", "10"),
+	newGenre("1063", "Contemporary Folk", "10"),
+	newGenre("1065", "Folk-Rock", "10"),
+	newGenre("1067", "Traditional Folk", "10"),
+	newGenre("16", "Soundtrack", MusicGenreID),
+	newGenre("19", "World", MusicGenreID),
+})
+
+func newGenre(id, name, parentID string) Genre {
+	return Genre{
+		ID:   id,
+		Type: "genres",
+		Attributes: GenreAttributes{
+			Name:     name,
+			ParentID: parentID,
+		},
+	}
+}
+
+// withParentNames preenche o nome do pai de cada gênero da tabela
+func withParentNames(table []Genre) []Genre {
+	names := make(map[string]string, len(table))
+	for _, g := range table {
+		names[g.ID] = g.Attributes.Name
+	}
+	for i := range table {
+		table[i].Attributes.ParentName = names[table[i].Attributes.ParentID]
+	}
+	return table
+}
+
+// Genres retorna todos os gêneros do catálogo, cada principal seguido dos seus subgêneros
+func Genres() []Genre {
+	return append([]Genre(nil), genres...)
+}
+
+// TopGenres retorna o gênero raiz seguido dos gêneros principais, os usados nos rankings
+func TopGenres() []Genre {
+	var top []Genre
+	for _, g := range genres {
+		if g.ID == MusicGenreID || g.Attributes.ParentID == MusicGenreID {
+			top = append(top, g)
+		}
+	}
+	return top
+}
+
+// FindGenre retorna o gênero com o ID informado
+func FindGenre(id string) (*Genre, bool) {
+	for _, g := range genres {
+		if g.ID == id {
+			return &g, true
+		}
+	}
+	return nil, false
+}
+
+// FindGenreByName retorna o gênero com o nome informado (sem diferenciar maiúsculas)
+func FindGenreByName(name string) (*Genre, bool) {
+	for _, g := range genres {
+		if strings.EqualFold(g.Attributes.Name, name) {
+			return &g, true
+		}
+	}
+	return nil, false
+}
+
+// GenreNames retorna os nomes dos gêneros seguidos do nome do gênero raiz,
+// como nos genreNames da Apple Music
+func GenreNames(genres []Genre) []string {
+	names := make([]string, 0, len(genres)+1)
+	for _, g := range genres {
+		if g.ID != MusicGenreID {
+			names = append(names, g.Attributes.Name)
+		}
+	}
+	return append(names, "Music")
+}
+
+// HasGenre informa se os genreNames de um recurso incluem o gênero com o ID
+// informado ou um dos seus subgêneros. Todo recurso pertence ao gênero raiz.
+func HasGenre(genreNames []string, id string) bool {
+	if id == MusicGenreID {
+		return true
+	}
+	for _, name := range genreNames {
+		if g, ok := FindGenreByName(name); ok && (g.ID == id || g.Attributes.ParentID == id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenres_Tree(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, g := range Genres() {
+		assert.False(t, seen[g.ID], "duplicate genre %s", g.ID)
+		seen[g.ID] = true
+		assert.Equal(t, "genres", g.Type)
+		if g.ID == MusicGenreID {
+			assert.Empty(t, g.Attributes.ParentID)
+			continue
+		}
+		parent, ok := FindGenre(g.Attributes.ParentID)
+		if assert.True(t, ok, "genre %s has an unknown parent", g.ID) {
+			assert.Equal(t, parent.Attributes.Name, g.Attributes.ParentName)
+		}
+	}
+}
+
+func TestTopGenres(t *testing.T) {
+	top := TopGenres()
+	require.NotEmpty(t, top)
+	assert.Equal(t, "Music", top[0].Attributes.Name)
+	for _, g := range top[1:] {
+		assert.Equal(t, MusicGenreID, g.Attributes.ParentID)
+	}
+}
+
+func TestFindGenre(t *testing.T) {
+	genre, ok := FindGenre("1152")
+	require.True(t, ok)
+	assert.Equal(t, "Hard Rock", genre.Attributes.Name)
+	assert.Equal(t, "Rock", genre.Attributes.ParentName)
+
+	genre, ok = FindGenreByName("hip-hop/rap")
+	require.True(t, ok)
+	assert.Equal(t, "18", genre.ID)
+
+	_, ok = FindGenre("999")
+	assert.False(t, ok)
+}
+
+func TestGenreNames(t *testing.T) {
+	pop, _ := FindGenre("14")
+	music, _ := FindGenre(MusicGenreID)
+	assert.Equal(t, []string{"Pop", "Music"}, GenreNames([]Genre{*pop, *music}))
+	assert.Equal(t, []string{"Music"}, GenreNames(nil))
+}
+
+func TestHasGenre(t *testing.T) {
+	names := []string{"Hard Rock", "Pop", "Music"}
+	assert.True(t, HasGenre(names, "1152"))
+	assert.True(t, HasGenre(names, "21"), "a subgenre belongs to its parent")
+	assert.True(t, HasGenre(names, "14"))
+	assert.True(t, HasGenre([]string{"Music"}, MusicGenreID))
+	assert.False(t, HasGenre(names, "18"))
+	assert.False(t, HasGenre(names, "1153"), "a sibling subgenre does not match")
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// PlayContainer é o álbum, playlist ou estação em que uma música foi tocada
+type PlayContainer struct {
+	Type string // "albums", "playlists" ou "stations"
+	ID   string
+	Name string // Nome exibido para estações, que não existem no catálogo
+}
+
+// PlayEvent registra que o usuário tocou uma música em PlayedAt, opcionalmente
+// a partir de um container
+type PlayEvent struct {
+	SongID    string
+	Container *PlayContainer
+	PlayedAt  time.Time
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// LibrarySong é uma música do catálogo adicionada à biblioteca de um usuário.
+// O ID é próprio da biblioteca ("i.XXXX"); Song guarda a música do catálogo.
+type LibrarySong struct {
+	ID        string
+	DateAdded time.Time
+	Song      Song
+}
+
+// LibraryAlbum é um álbum do catálogo adicionado à biblioteca de um usuário.
+// O ID é próprio da biblioteca ("l.XXXX"); Album guarda o álbum do catálogo.
+type LibraryAlbum struct {
+	ID        string
+	DateAdded time.Time
+	Album     Album
+}
+
+// LibraryArtist é um artista das músicas e álbuns da biblioteca de um usuário,
+// identificado pelo nome, com um ID próprio da biblioteca ("r.XXXX")
+type LibraryArtist struct {
+	ID        string
+	DateAdded time.Time
+	Name      string
+}
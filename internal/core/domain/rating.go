@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// RatingValue é o valor de uma avaliação, como na Apple Music API
+type RatingValue int
+
+const (
+	// RatingLove indica que o usuário amou o recurso
+	RatingLove RatingValue = 1
+	// RatingDislike indica que o usuário não gostou do recurso
+	RatingDislike RatingValue = -1
+)
+
+// Valid informa se o valor é uma das avaliações aceitas
+func (v RatingValue) Valid() bool {
+	return v == RatingLove || v == RatingDislike
+}
+
+// Rating é a avaliação de um usuário para um recurso, identificado pelo tipo
+// ("songs", "albums" ou "library-playlists") e pelo ID
+type Rating struct {
+	ResourceType string      `json:"resourceType"`
+	ResourceID   string      `json:"resourceId"`
+	Value        RatingValue `json:"value"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Políticas de conteúdo explícito de um storefront
+const (
+	ExplicitContentAllowed    = "allowed"
+	ExplicitContentOptIn      = "opt-in"
+	ExplicitContentProhibited = "prohibited"
+)
+
+// Storefront represents an Apple Music storefront (country catalog)
+type Storefront struct {
+	ID         string               `json:"id"`
+	Type       string               `json:"type"`
+	Href       string               `json:"href"`
+	Attributes StorefrontAttributes `json:"attributes"`
+}
+
+// StorefrontAttributes represents the attributes of a storefront
+type StorefrontAttributes struct {
+	Name                  string   `json:"name"`
+	DefaultLanguageTag    string   `json:"defaultLanguageTag"`
+	SupportedLanguageTags []string `json:"supportedLanguageTags"`
+	ExplicitContentPolicy string   `json:"explicitContentPolicy"`
+}
+
+// storefronts é a tabela de storefronts suportados pelo simulador
+var storefronts = []Storefront{
+	newStorefront("au", "Australia", ExplicitContentAllowed, "en-AU", "en-GB"),
+	newStorefront("br", "Brazil", ExplicitContentAllowed, "pt-BR", "en-GB"),
+	newStorefront("ca", "Canada", ExplicitContentAllowed, "en-CA", "fr-CA"),
+	newStorefront("de", "Germany", ExplicitContentAllowed, "de-DE", "en-GB"),
+	newStorefront("es", "Spain", ExplicitContentAllowed, "es-ES", "ca-ES", "en-GB"),
+	newStorefront("fr", "France", ExplicitContentAllowed, "fr-FR", "en-GB"),
+	newStorefront("gb", "United Kingdom", ExplicitContentAllowed, "en-GB"),
+	newStorefront("jp", "Japan", ExplicitContentAllowed, "ja", "en-US"),
+	newStorefront("kr", "Republic of Korea", ExplicitContentOptIn, "ko-KR", "en-GB"),
+	newStorefront("mx", "Mexico", ExplicitContentAllowed, "es-MX", "en-GB"),
+	newStorefront("pt", "Portugal", ExplicitContentAllowed, "pt-PT", "en-GB"),
+	newStorefront("us", "United States", ExplicitContentAllowed, "en-US", "es-MX"),
+}
+
+func newStorefront(id, name, explicitContentPolicy string, languages ...string) Storefront {
+	return Storefront{
+		ID:   id,
+		Type: "storefronts",
+		Href: fmt.Sprintf("/v1/storefronts/%s", id),
+		Attributes: StorefrontAttributes{
+			Name:                  name,
+			DefaultLanguageTag:    languages[0],
+			SupportedLanguageTags: languages,
+			ExplicitContentPolicy: explicitContentPolicy,
+		},
+	}
+}
+
+// Storefronts retorna todos os storefronts suportados, ordenados pelo ID
+func Storefronts() []Storefront {
+	return append([]Storefront(nil), storefronts...)
+}
+
+// FindStorefront retorna o storefront com o ID informado (sem diferenciar maiúsculas)
+func FindStorefront(id string) (*Storefront, bool) {
+	for _, sf := range storefronts {
+		if strings.EqualFold(sf.ID, id) {
+			return &sf, true
+		}
+	}
+	return nil, false
+}
+
+// SupportedLanguage retorna a tag canônica do idioma se o storefront o suportar.
+// Uma tag vazia resulta no idioma padrão do storefront.
+func (s *Storefront) SupportedLanguage(tag string) (string, bool) {
+	if tag == "" {
+		return s.Attributes.DefaultLanguageTag, true
+	}
+	for _, supported := range s.Attributes.SupportedLanguageTags {
+		if strings.EqualFold(supported, tag) {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// CatalogHref retorna o href de um recurso do catálogo no storefront informado
+func CatalogHref(storefront, resourceType, id string) string {
+	return fmt.Sprintf("/v1/catalog/%s/%s/%s", storefront, resourceType, id)
+}
+
+// LibraryHref retorna o href de um recurso da biblioteca do usuário
+func LibraryHref(resourceType, id string) string {
+	return fmt.Sprintf("/v1/me/library/%s/%s", resourceType, id)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// User é um usuário simulado do Apple Music. Os recursos pessoais, como a
+// biblioteca, pertencem a um usuário e são acessados com um Music-User-Token.
+type User struct {
+	ID         string
+	Storefront string
+}
+
+// UserToken é um Music-User-Token emitido pelo simulador para um usuário
+type UserToken struct {
+	Token     string
+	User      User
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Expired informa se o token já expirou em now
+func (t *UserToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
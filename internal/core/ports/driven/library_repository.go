@@ -0,0 +1,43 @@
+package driven
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// LibraryRepository guarda as músicas, álbuns e artistas da biblioteca de cada
+// usuário. Os itens são listados na ordem em que foram adicionados.
+type LibraryRepository interface {
+	// ListSongs retorna as músicas da biblioteca do usuário
+	ListSongs(ctx context.Context, userID string) ([]domain.LibrarySong, error)
+
+	// GetSong retorna uma música da biblioteca pelo seu ID na biblioteca.
+	// Retorna domain.ErrNotFound se a música não existir.
+	GetSong(ctx context.Context, userID, id string) (*domain.LibrarySong, error)
+
+	// AddSong guarda a música se a música do catálogo ainda não estiver na
+	// biblioteca e retorna a versão guardada
+	AddSong(ctx context.Context, userID string, song domain.LibrarySong) (*domain.LibrarySong, error)
+
+	// ListAlbums retorna os álbuns da biblioteca do usuário
+	ListAlbums(ctx context.Context, userID string) ([]domain.LibraryAlbum, error)
+
+	// GetAlbum retorna um álbum da biblioteca pelo seu ID na biblioteca.
+	// Retorna domain.ErrNotFound se o álbum não existir.
+	GetAlbum(ctx context.Context, userID, id string) (*domain.LibraryAlbum, error)
+
+	// AddAlbum guarda o álbum se o álbum do catálogo ainda não estiver na
+	// biblioteca e retorna a versão guardada
+	AddAlbum(ctx context.Context, userID string, album domain.LibraryAlbum) (*domain.LibraryAlbum, error)
+
+	// ListArtists retorna os artistas da biblioteca do usuário
+	ListArtists(ctx context.Context, userID string) ([]domain.LibraryArtist, error)
+
+	// GetArtist retorna um artista da biblioteca pelo seu ID na biblioteca.
+	// Retorna domain.ErrNotFound se o artista não existir.
+	GetArtist(ctx context.Context, userID, id string) (*domain.LibraryArtist, error)
+
+	// AddArtist guarda o artista se ainda não houver um artista com o mesmo nome,
+	// sem diferenciar maiúsculas, e retorna a versão guardada
+	AddArtist(ctx context.Context, userID string, artist domain.LibraryArtist) (*domain.LibraryArtist, error)
+}
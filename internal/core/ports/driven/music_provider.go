@@ -2,6 +2,7 @@ package driven
 
 import (
 	"applemusic-api-simulator/internal/core/domain"
+	"context"
 )
 
 // ProviderSearchResults contém os resultados de uma busca diretamente do provedor de dados.
@@ -15,14 +16,66 @@ type ProviderSearchResults struct {
 	Artists []domain.Artist
 }
 
-// MusicProvider define a interface para provedores de música
+// AlbumWithTracks contém um álbum e a sua tracklist completa
+type AlbumWithTracks struct {
+	Album  domain.Album  `json:"album"`
+	Tracks []domain.Song `json:"tracks"`
+}
+
+// ArtistWithAlbums contém um artista e os seus primeiros álbuns
+type ArtistWithAlbums struct {
+	Artist domain.Artist  `json:"artist"`
+	Albums []domain.Album `json:"albums"`
+}
+
+// MusicProvider define a interface para provedores de música.
+// O parâmetro language é uma tag BCP 47 (ex.: "pt-BR"); provedores que possuem
+// atributos localizados devem retorná-los nesse idioma quando disponíveis.
+// As chamadas devem ser interrompidas quando o contexto for cancelado ou expirar.
 type MusicProvider interface {
 	// SearchSongs busca músicas com base no termo de busca
-	SearchSongs(term string, limit, offset int) ([]domain.Song, error)
+	SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error)
 
 	// SearchAlbums busca álbuns com base no termo de busca
-	SearchAlbums(term string, limit, offset int) ([]domain.Album, error)
+	SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error)
 
 	// SearchArtists busca artistas com base no termo de busca
-	SearchArtists(term string, limit, offset int) ([]domain.Artist, error)
+	SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error)
+
+	// GetSong busca uma música pelo seu ID no catálogo.
+	// Retorna domain.ErrNotFound se a música não existir.
+	GetSong(ctx context.Context, language, id string) (*domain.Song, error)
+
+	// GetAlbum busca um álbum pelo seu ID no catálogo.
+	// Retorna domain.ErrNotFound se o álbum não existir.
+	GetAlbum(ctx context.Context, language, id string) (*domain.Album, error)
+
+	// GetArtist busca um artista pelo seu ID no catálogo.
+	// Retorna domain.ErrNotFound se o artista não existir.
+	GetArtist(ctx context.Context, language, id string) (*domain.Artist, error)
+
+	// GetAlbumWithTracks busca um álbum junto com a sua tracklist completa, com a
+	// mesma consulta ao provedor. Retorna domain.ErrNotFound se o álbum não existir.
+	GetAlbumWithTracks(ctx context.Context, language, id string) (*AlbumWithTracks, error)
+
+	// GetArtistWithAlbums busca um artista junto com os seus primeiros limit álbuns,
+	// resolvendo o artista uma única vez. Retorna domain.ErrNotFound se o artista não existir.
+	GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*ArtistWithAlbums, error)
+
+	// GetAlbumTracks retorna a lista completa de faixas de um álbum.
+	// Retorna domain.ErrNotFound se o álbum não existir.
+	GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error)
+
+	// GetArtistAlbums retorna os álbuns de um artista, paginados por limit e offset.
+	// Retorna domain.ErrNotFound se o artista não existir.
+	GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error)
+
+	// GetTopSongs retorna as músicas mais tocadas, em ordem de posição, paginadas
+	// por limit e offset. Se genre não for vazio o ranking é o do gênero; senão é
+	// o do país do storefront, ou o mundial se storefront também for vazio.
+	GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error)
+
+	// GetTopAlbums retorna os álbuns mais tocados, em ordem de posição, paginados
+	// por limit e offset. Se genre não for vazio o ranking é o do gênero.
+	GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error)
 }
@@ -0,0 +1,15 @@
+package driven
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// PlayHistoryRepository guarda o histórico de reprodução de cada usuário
+type PlayHistoryRepository interface {
+	// AddPlays registra eventos de reprodução do usuário, em qualquer ordem
+	AddPlays(ctx context.Context, userID string, plays []domain.PlayEvent) error
+
+	// ListPlays retorna o histórico do usuário, do evento mais recente para o mais antigo
+	ListPlays(ctx context.Context, userID string) ([]domain.PlayEvent, error)
+}
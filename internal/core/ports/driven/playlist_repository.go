@@ -0,0 +1,25 @@
+package driven
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// PlaylistRepository guarda as playlists da biblioteca de cada usuário.
+// As playlists retornadas são cópias e podem ser alteradas livremente.
+type PlaylistRepository interface {
+	// ListPlaylists retorna as playlists do usuário na ordem em que foram criadas
+	ListPlaylists(ctx context.Context, userID string) ([]domain.Playlist, error)
+
+	// GetPlaylist retorna uma playlist do usuário pelo seu ID.
+	// Retorna domain.ErrNotFound se a playlist não existir.
+	GetPlaylist(ctx context.Context, userID, id string) (*domain.Playlist, error)
+
+	// CreatePlaylist guarda uma nova playlist do usuário
+	CreatePlaylist(ctx context.Context, userID string, playlist domain.Playlist) error
+
+	// UpdatePlaylist aplica update a uma cópia da playlist e a guarda se update não
+	// retornar erro, de forma atômica em relação às demais alterações.
+	// Retorna domain.ErrNotFound se a playlist não existir.
+	UpdatePlaylist(ctx context.Context, userID, id string, update func(*domain.Playlist) error) (*domain.Playlist, error)
+}
@@ -0,0 +1,20 @@
+package driven
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// RatingRepository guarda as avaliações de cada usuário, uma por recurso
+type RatingRepository interface {
+	// GetRating retorna a avaliação do usuário para o recurso.
+	// Retorna domain.ErrNotFound se o recurso não foi avaliado.
+	GetRating(ctx context.Context, userID, resourceType, id string) (*domain.Rating, error)
+
+	// PutRating guarda a avaliação, substituindo a anterior do mesmo recurso
+	PutRating(ctx context.Context, userID string, rating domain.Rating) error
+
+	// DeleteRating remove a avaliação do usuário para o recurso.
+	// Retorna domain.ErrNotFound se o recurso não foi avaliado.
+	DeleteRating(ctx context.Context, userID, resourceType, id string) error
+}
@@ -0,0 +1,43 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// MostPlayedChart é o único ranking do simulador, como o padrão da Apple Music API
+const MostPlayedChart = "most-played"
+
+// Limites de paginação dos rankings, como os da Apple Music API
+const (
+	DefaultChartLimit = 20
+	MaxChartLimit     = 50
+)
+
+// ChartParameters representa os parâmetros de uma consulta aos rankings
+type ChartParameters struct {
+	CatalogParameters
+	Types []SearchResultType
+	Genre string // ID do gênero; vazio para o ranking geral
+	Page  PageParameters
+}
+
+// Chart é um ranking no formato da Apple Music API, com os recursos em ordem de posição
+type Chart[T any] struct {
+	Chart   string `json:"chart"`
+	Name    string `json:"name"`
+	OrderID string `json:"orderId"`
+	Page[T]
+}
+
+// ChartResults contém os rankings de cada tipo solicitado
+type ChartResults struct {
+	Songs  []Chart[domain.Song]  `json:"songs,omitempty"`
+	Albums []Chart[domain.Album] `json:"albums,omitempty"`
+}
+
+// ChartService define a interface para o serviço de rankings do catálogo
+type ChartService interface {
+	// GetCharts retorna o ranking de cada tipo solicitado (músicas e álbuns)
+	GetCharts(ctx context.Context, params ChartParameters) (*ChartResults, error)
+}
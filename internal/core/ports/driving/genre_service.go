@@ -0,0 +1,22 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// Número máximo de IDs aceitos em uma busca de múltiplos gêneros
+const MaxGenreIDs = 100
+
+// GenreService define a interface para o serviço de gêneros do catálogo
+type GenreService interface {
+	// ListTopGenres retorna o gênero raiz seguido dos gêneros principais
+	ListTopGenres(catalog CatalogParameters) []domain.Genre
+
+	// GetGenre retorna um gênero pelo seu ID.
+	// Retorna domain.ErrNotFound se o gênero não existir.
+	GetGenre(catalog CatalogParameters, id string) (*domain.Genre, error)
+
+	// GetGenres retorna os gêneros com os IDs informados, na ordem dos IDs e
+	// omitindo os inexistentes
+	GetGenres(catalog CatalogParameters, ids []string) []domain.Genre
+}
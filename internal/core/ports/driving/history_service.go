@@ -0,0 +1,46 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// Limites de paginação do histórico de reprodução, como os da Apple Music API
+const (
+	DefaultHistoryLimit   = 10
+	MaxRecentPlayedLimit  = 10
+	MaxRecentTracksLimit  = 30
+	MaxHeavyRotationLimit = 10
+)
+
+// Tipos de container aceitos nos eventos de reprodução
+const (
+	PlayContainerAlbums    = "albums"
+	PlayContainerPlaylists = "playlists"
+	PlayContainerStations  = "stations"
+)
+
+// PlayedResource é um container tocado pelo usuário. Apenas um dos campos é preenchido.
+type PlayedResource struct {
+	Album    *domain.Album
+	Playlist *domain.Playlist
+	Station  *domain.Station
+}
+
+// HistoryService define a interface para o serviço de histórico de reprodução
+type HistoryService interface {
+	// RecordPlays registra eventos de reprodução do usuário. Nenhum evento é
+	// registrado se alguma música não existir no catálogo ou se algum container
+	// tiver um tipo desconhecido. Retorna domain.ErrNotFound se o usuário não existir.
+	RecordPlays(ctx context.Context, userID string, plays []domain.PlayEvent) error
+
+	// RecentlyPlayed retorna os containers tocados mais recentemente, sem repetições
+	RecentlyPlayed(ctx context.Context, user *domain.User, page PageParameters) (*Page[PlayedResource], error)
+
+	// RecentlyPlayedTracks retorna as músicas tocadas mais recentemente, sem repetições
+	RecentlyPlayedTracks(ctx context.Context, user *domain.User, page PageParameters) (*Page[domain.Song], error)
+
+	// HeavyRotation retorna os containers mais tocados no período recente,
+	// desempatados pela reprodução mais recente
+	HeavyRotation(ctx context.Context, user *domain.User, page PageParameters) (*Page[PlayedResource], error)
+}
@@ -0,0 +1,72 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// CreatePlaylistParameters descreve uma nova playlist da biblioteca
+type CreatePlaylistParameters struct {
+	Name        string
+	Description string
+	SongIDs     []string // Músicas do catálogo que formam as faixas iniciais
+}
+
+// AddToLibraryParameters lista os recursos do catálogo a adicionar à biblioteca
+type AddToLibraryParameters struct {
+	SongIDs  []string
+	AlbumIDs []string
+}
+
+// LibraryService define a interface para o serviço da biblioteca do usuário
+type LibraryService interface {
+	// AddToLibrary adiciona músicas e álbuns do catálogo à biblioteca do usuário,
+	// junto com os seus artistas. Recursos já adicionados são ignorados, e nada é
+	// adicionado se algum recurso não existir no catálogo.
+	AddToLibrary(ctx context.Context, user *domain.User, params AddToLibraryParameters) error
+
+	// ListSongs retorna uma página das músicas da biblioteca do usuário
+	ListSongs(ctx context.Context, user *domain.User, page PageParameters) (*Page[domain.LibrarySong], error)
+
+	// GetSong retorna uma música da biblioteca pelo seu ID na biblioteca.
+	// Retorna domain.ErrNotFound se a música não existir.
+	GetSong(ctx context.Context, user *domain.User, id string) (*domain.LibrarySong, error)
+
+	// ListAlbums retorna uma página dos álbuns da biblioteca do usuário
+	ListAlbums(ctx context.Context, user *domain.User, page PageParameters) (*Page[domain.LibraryAlbum], error)
+
+	// GetAlbum retorna um álbum da biblioteca pelo seu ID na biblioteca.
+	// Retorna domain.ErrNotFound se o álbum não existir.
+	GetAlbum(ctx context.Context, user *domain.User, id string) (*domain.LibraryAlbum, error)
+
+	// ListArtists retorna uma página dos artistas da biblioteca do usuário
+	ListArtists(ctx context.Context, user *domain.User, page PageParameters) (*Page[domain.LibraryArtist], error)
+
+	// GetArtist retorna um artista da biblioteca pelo seu ID na biblioteca.
+	// Retorna domain.ErrNotFound se o artista não existir.
+	GetArtist(ctx context.Context, user *domain.User, id string) (*domain.LibraryArtist, error)
+
+	// ListPlaylists retorna uma página das playlists da biblioteca do usuário
+	ListPlaylists(ctx context.Context, user *domain.User, page PageParameters) (*Page[domain.Playlist], error)
+
+	// CreatePlaylist cria uma playlist na biblioteca do usuário.
+	// Retorna um erro domain.KindInvalidParameter se alguma música não existir no catálogo.
+	CreatePlaylist(ctx context.Context, user *domain.User, params CreatePlaylistParameters) (*domain.Playlist, error)
+
+	// GetPlaylist retorna uma playlist da biblioteca do usuário.
+	// Retorna domain.ErrNotFound se a playlist não existir.
+	GetPlaylist(ctx context.Context, user *domain.User, id string) (*domain.Playlist, error)
+
+	// GetPlaylistTracks retorna uma página das faixas de uma playlist da biblioteca.
+	// Retorna domain.ErrNotFound se a playlist não existir.
+	GetPlaylistTracks(ctx context.Context, user *domain.User, id string, page PageParameters) (*Page[domain.Track], error)
+
+	// AddPlaylistTracks adiciona músicas do catálogo ao fim da playlist. Nenhuma
+	// música é adicionada se alguma não existir ou se a playlist ficar com mais
+	// de domain.MaxTracksPerPlaylist faixas.
+	AddPlaylistTracks(ctx context.Context, user *domain.User, id string, songIDs []string) (*domain.Playlist, error)
+
+	// RemovePlaylistTrack remove todas as ocorrências de uma faixa da playlist.
+	// Retorna domain.ErrNotFound se a playlist não existir ou não tiver a faixa.
+	RemovePlaylistTrack(ctx context.Context, user *domain.User, id, trackID string) error
+}
@@ -2,6 +2,7 @@ package driving
 
 import (
 	"applemusic-api-simulator/internal/core/domain"
+	"context"
 )
 
 // SearchResultType representa os tipos de resultados de busca suportados
@@ -13,9 +14,17 @@ const (
 	AlbumsType  SearchResultType = "albums"
 )
 
+// CatalogParameters identifica o storefront e o idioma de uma requisição ao catálogo
+type CatalogParameters struct {
+	Storefront string
+	Language   string // Tag BCP 47 suportada pelo storefront (ex.: "pt-BR")
+}
+
 // SearchParameters representa os parâmetros de uma busca
 type SearchParameters struct {
+	CatalogParameters
 	Term   string
+	Genre  string // ID do gênero que filtra os resultados, incluindo os seus subgêneros
 	Limit  int
 	Offset int
 	Types  []SearchResultType
@@ -25,10 +34,91 @@ type SearchResults struct {
 	Artists []domain.Artist `json:"artists"`
 	Songs   []domain.Song   `json:"songs"`
 	Albums  []domain.Album  `json:"albums"`
+	// Failures lista os tipos cuja busca falhou; os demais tipos continuam válidos
+	Failures []SearchFailure `json:"-"`
+}
+
+// SearchFailure descreve a falha da busca de um dos tipos solicitados
+type SearchFailure struct {
+	Type SearchResultType
+	Err  error
+}
+
+// Failed informa se a busca do tipo informado falhou
+func (r *SearchResults) Failed(searchType SearchResultType) bool {
+	for _, failure := range r.Failures {
+		if failure.Type == searchType {
+			return true
+		}
+	}
+	return false
+}
+
+// Limites de paginação dos relacionamentos
+const (
+	DefaultPageLimit = 25
+	MaxPageLimit     = 100
+)
+
+// Número máximo de IDs aceitos em uma busca de múltiplos recursos
+const (
+	MaxSongIDs   = 300
+	MaxAlbumIDs  = 100
+	MaxArtistIDs = 25
+)
+
+// PageParameters representa os parâmetros de paginação de um relacionamento
+type PageParameters struct {
+	Limit  int
+	Offset int
+}
+
+// Page representa uma página de um relacionamento entre recursos
+type Page[T any] struct {
+	Href string `json:"href"`
+	Next string `json:"next,omitempty"`
+	Data []T    `json:"data"`
 }
 
 // MusicService define a interface para o serviço de música
 type MusicService interface {
-	// Search realiza uma busca por músicas, álbuns e artistas
-	Search(params SearchParameters) (*SearchResults, error)
+	// Search realiza uma busca por músicas, álbuns e artistas.
+	// Tipos cuja busca falhar são informados em SearchResults.Failures; um erro
+	// é retornado apenas se a busca falhar para todos os tipos solicitados.
+	Search(ctx context.Context, params SearchParameters) (*SearchResults, error)
+
+	// GetSong retorna uma música do catálogo pelo seu ID
+	GetSong(ctx context.Context, catalog CatalogParameters, id string) (*domain.Song, error)
+
+	// GetAlbum retorna um álbum do catálogo pelo seu ID
+	GetAlbum(ctx context.Context, catalog CatalogParameters, id string) (*domain.Album, error)
+
+	// GetArtist retorna um artista do catálogo pelo seu ID
+	GetArtist(ctx context.Context, catalog CatalogParameters, id string) (*domain.Artist, error)
+
+	// GetSongs retorna as músicas dos IDs informados, na mesma ordem.
+	// IDs inexistentes são omitidos do resultado.
+	GetSongs(ctx context.Context, catalog CatalogParameters, ids []string) ([]domain.Song, error)
+
+	// GetAlbums retorna os álbuns dos IDs informados, na mesma ordem.
+	// IDs inexistentes são omitidos do resultado.
+	GetAlbums(ctx context.Context, catalog CatalogParameters, ids []string) ([]domain.Album, error)
+
+	// GetArtists retorna os artistas dos IDs informados, na mesma ordem.
+	// IDs inexistentes são omitidos do resultado.
+	GetArtists(ctx context.Context, catalog CatalogParameters, ids []string) ([]domain.Artist, error)
+
+	// GetSongsByISRC retorna as músicas com os ISRCs informados.
+	// Apenas códigos já emitidos pelo simulador podem ser resolvidos.
+	GetSongsByISRC(ctx context.Context, catalog CatalogParameters, isrcs []string) ([]domain.Song, error)
+
+	// GetAlbumsByUPC retorna os álbuns com os UPCs informados.
+	// Apenas códigos já emitidos pelo simulador podem ser resolvidos.
+	GetAlbumsByUPC(ctx context.Context, catalog CatalogParameters, upcs []string) ([]domain.Album, error)
+
+	// GetAlbumTracks retorna uma página das faixas de um álbum
+	GetAlbumTracks(ctx context.Context, catalog CatalogParameters, id string, page PageParameters) (*Page[domain.Song], error)
+
+	// GetArtistAlbums retorna uma página dos álbuns de um artista
+	GetArtistAlbums(ctx context.Context, catalog CatalogParameters, id string, page PageParameters) (*Page[domain.Album], error)
 }
@@ -0,0 +1,37 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+)
+
+// Tipos de recurso que podem ser avaliados
+const (
+	RatingTypeSongs            = "songs"
+	RatingTypeAlbums           = "albums"
+	RatingTypeLibraryPlaylists = "library-playlists"
+)
+
+// MaxRatingIDs é o número máximo de recursos na busca de várias avaliações
+const MaxRatingIDs = 100
+
+// RatingService define a interface para o serviço de avaliações do usuário.
+// Tipos de recurso que não podem ser avaliados resultam em domain.ErrNotFound.
+type RatingService interface {
+	// GetRating retorna a avaliação do usuário para o recurso.
+	// Retorna domain.ErrNotFound se o recurso não foi avaliado.
+	GetRating(ctx context.Context, user *domain.User, resourceType, id string) (*domain.Rating, error)
+
+	// GetRatings retorna as avaliações do usuário para os recursos, na ordem dos
+	// IDs. Recursos que não foram avaliados são omitidos.
+	GetRatings(ctx context.Context, user *domain.User, resourceType string, ids []string) ([]domain.Rating, error)
+
+	// SetRating avalia o recurso, substituindo a avaliação anterior. Retorna um
+	// erro domain.KindInvalidParameter se o valor não for aceito ou se o recurso
+	// não existir.
+	SetRating(ctx context.Context, user *domain.User, resourceType, id string, value domain.RatingValue) (*domain.Rating, error)
+
+	// DeleteRating remove a avaliação do usuário para o recurso.
+	// Retorna domain.ErrNotFound se o recurso não foi avaliado.
+	DeleteRating(ctx context.Context, user *domain.User, resourceType, id string) error
+}
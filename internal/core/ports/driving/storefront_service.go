@@ -0,0 +1,15 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// StorefrontService define a interface para o serviço de storefronts
+type StorefrontService interface {
+	// ListStorefronts retorna todos os storefronts suportados
+	ListStorefronts() []domain.Storefront
+
+	// GetStorefront retorna um storefront pelo seu ID.
+	// Retorna domain.ErrNotFound se o storefront não existir.
+	GetStorefront(id string) (*domain.Storefront, error)
+}
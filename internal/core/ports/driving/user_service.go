@@ -0,0 +1,33 @@
+package driving
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+	"context"
+	"time"
+)
+
+// IssueUserTokenParameters descreve o Music-User-Token a ser emitido
+type IssueUserTokenParameters struct {
+	UserID     string        // Usuário do token; vazio cria um novo usuário
+	Storefront string        // Storefront do usuário; vazio usa o padrão
+	TTL        time.Duration // Validade do token; zero usa a validade padrão
+}
+
+// UserService define a interface para o serviço de usuários simulados
+type UserService interface {
+	// IssueToken emite um Music-User-Token. Se o usuário informado não existir,
+	// ele é criado; caso contrário, o storefront informado substitui o atual.
+	IssueToken(ctx context.Context, params IssueUserTokenParameters) (*domain.UserToken, error)
+
+	// Authenticate retorna o usuário do token.
+	// Retorna um erro domain.KindForbidden se o token for desconhecido, revogado ou expirado.
+	Authenticate(ctx context.Context, token string) (*domain.User, error)
+
+	// GetUser retorna um usuário pelo ID.
+	// Retorna domain.ErrNotFound se o usuário não existir.
+	GetUser(ctx context.Context, id string) (*domain.User, error)
+
+	// RevokeToken invalida um token.
+	// Retorna domain.ErrNotFound se o token não existir.
+	RevokeToken(ctx context.Context, token string) error
+}
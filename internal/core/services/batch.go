@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// Número máximo de buscas simultâneas ao provedor em uma busca de múltiplos IDs
+const maxConcurrentLookups = 8
+
+func (s *MusicService) GetSongs(ctx context.Context, catalog driving.CatalogParameters, ids []string) ([]domain.Song, error) {
+	return fetchAll(ctx, ids, func(id string) (*domain.Song, error) {
+		return s.GetSong(ctx, catalog, id)
+	})
+}
+
+func (s *MusicService) GetAlbums(ctx context.Context, catalog driving.CatalogParameters, ids []string) ([]domain.Album, error) {
+	return fetchAll(ctx, ids, func(id string) (*domain.Album, error) {
+		return s.GetAlbum(ctx, catalog, id)
+	})
+}
+
+func (s *MusicService) GetArtists(ctx context.Context, catalog driving.CatalogParameters, ids []string) ([]domain.Artist, error) {
+	return fetchAll(ctx, ids, func(id string) (*domain.Artist, error) {
+		return s.GetArtist(ctx, catalog, id)
+	})
+}
+
+func (s *MusicService) GetSongsByISRC(ctx context.Context, catalog driving.CatalogParameters, isrcs []string) ([]domain.Song, error) {
+	var ids []string
+	for _, isrc := range isrcs {
+		for _, id := range s.codes.songIDsByISRC(isrc) {
+			ids = appendUnique(ids, id)
+		}
+	}
+	return s.GetSongs(ctx, catalog, ids)
+}
+
+func (s *MusicService) GetAlbumsByUPC(ctx context.Context, catalog driving.CatalogParameters, upcs []string) ([]domain.Album, error) {
+	var ids []string
+	for _, upc := range upcs {
+		for _, id := range s.codes.albumIDsByUPC(upc) {
+			ids = appendUnique(ids, id)
+		}
+	}
+	return s.GetAlbums(ctx, catalog, ids)
+}
+
+// fetchAll executa get para cada ID de forma concorrente, preservando a ordem
+// dos IDs no resultado e omitindo os recursos inexistentes.
+// Se o contexto for cancelado, os IDs ainda não consultados não são buscados.
+func fetchAll[T any](ctx context.Context, ids []string, get func(id string) (*T, error)) ([]T, error) {
+	found := make([]*T, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentLookups)
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			found[i], errs[i] = get(id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	results := make([]T, 0, len(ids))
+	for i := range ids {
+		if errs[i] != nil {
+			if errors.Is(errs[i], domain.ErrNotFound) {
+				continue
+			}
+			return nil, errs[i]
+		}
+		results = append(results, *found[i])
+	}
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+type ChartService struct {
+	musicProvider driven.MusicProvider
+}
+
+// NewChartService cria o serviço de rankings. Para que os códigos dos itens dos
+// rankings possam ser buscados por filter[isrc] e filter[upc], o provedor deve ser
+// o mesmo *CodedProvider do MusicService.
+func NewChartService(musicProvider driven.MusicProvider) driving.ChartService {
+	return &ChartService{
+		musicProvider: codedProviderFor(musicProvider),
+	}
+}
+
+func (s *ChartService) GetCharts(ctx context.Context, params driving.ChartParameters) (*driving.ChartResults, error) {
+	// O ranking do gênero raiz é o ranking geral
+	genre := params.Genre
+	if genre == domain.MusicGenreID {
+		genre = ""
+	}
+
+	results := &driving.ChartResults{}
+	for _, chartType := range uniqueTypes(params.Types) {
+		// Buscar um item a mais para saber se existe uma próxima página
+		limit, offset := params.Page.Limit+1, params.Page.Offset
+		switch chartType {
+		case driving.SongsType:
+			songs, err := s.musicProvider.GetTopSongs(ctx, params.Language, params.Storefront, genre, limit, offset)
+			if err != nil {
+				return nil, fmt.Errorf("error getting top songs: %w", err)
+			}
+			chart := newChart(params, chartType, "Top Songs", songs)
+			songHrefs(params.Storefront, chart.Data)
+			results.Songs = append(results.Songs, chart)
+		case driving.AlbumsType:
+			albums, err := s.musicProvider.GetTopAlbums(ctx, params.Language, genre, limit, offset)
+			if err != nil {
+				return nil, fmt.Errorf("error getting top albums: %w", err)
+			}
+			chart := newChart(params, chartType, "Top Albums", albums)
+			albumHrefs(params.Storefront, chart.Data)
+			results.Albums = append(results.Albums, chart)
+		default:
+			return nil, domain.InvalidParameter("types", fmt.Sprintf("%s charts are not supported", chartType))
+		}
+	}
+	return results, nil
+}
+
+// newChart monta o ranking de um tipo a partir de até limit+1 itens, em que o
+// item excedente indica a existência de uma próxima página
+func newChart[T any](params driving.ChartParameters, chartType driving.SearchResultType, name string, items []T) driving.Chart[T] {
+	if items == nil {
+		items = []T{}
+	}
+	hasNext := len(items) > params.Page.Limit
+	if hasNext {
+		items = items[:params.Page.Limit]
+	}
+
+	query := url.Values{}
+	query.Set("chart", driving.MostPlayedChart)
+	query.Set("types", string(chartType))
+	if params.Genre != "" {
+		query.Set("genre", params.Genre)
+	}
+	href := fmt.Sprintf("/v1/catalog/%s/charts?%s", params.Storefront, query.Encode())
+
+	var next string
+	if hasNext {
+		next = fmt.Sprintf("%s&offset=%d", href, params.Page.Offset+params.Page.Limit)
+		if params.Page.Limit != driving.DefaultChartLimit {
+			next += fmt.Sprintf("&limit=%d", params.Page.Limit)
+		}
+	}
+
+	return driving.Chart[T]{
+		Chart:   driving.MostPlayedChart,
+		Name:    name,
+		OrderID: fmt.Sprintf("%s:%s", driving.MostPlayedChart, chartType),
+		Page:    driving.Page[T]{Href: href, Next: next, Data: items},
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartService_GetCharts(t *testing.T) {
+	service := NewChartService(newCatalog(30))
+	ctx := context.Background()
+
+	results, err := service.GetCharts(ctx, driving.ChartParameters{
+		CatalogParameters: us,
+		Types:             []driving.SearchResultType{driving.SongsType, driving.AlbumsType, driving.SongsType},
+		Page:              driving.PageParameters{Limit: driving.DefaultChartLimit},
+	})
+	require.NoError(t, err)
+	require.Len(t, results.Songs, 1)
+	require.Len(t, results.Albums, 1)
+
+	songs := results.Songs[0]
+	assert.Equal(t, "most-played", songs.Chart)
+	assert.Equal(t, "Top Songs", songs.Name)
+	assert.Equal(t, "most-played:songs", songs.OrderID)
+	assert.Equal(t, "/v1/catalog/us/charts?chart=most-played&types=songs", songs.Href)
+	assert.Equal(t, "/v1/catalog/us/charts?chart=most-played&types=songs&offset=20", songs.Next)
+	require.Len(t, songs.Data, 20)
+	assert.Equal(t, "/v1/catalog/us/songs/song-0", songs.Data[0].Href)
+	assert.Equal(t, domain.ISRCFor("song-0"), songs.Data[0].Attributes.ISRC)
+	assert.Equal(t, "Top Albums", results.Albums[0].Name)
+
+	// Última página, com gênero
+	results, err = service.GetCharts(ctx, driving.ChartParameters{
+		CatalogParameters: us,
+		Types:             []driving.SearchResultType{driving.AlbumsType},
+		Genre:             "18",
+		Page:              driving.PageParameters{Limit: 10, Offset: 20},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results.Songs)
+	albums := results.Albums[0]
+	assert.Equal(t, "/v1/catalog/us/charts?chart=most-played&genre=18&types=albums", albums.Href)
+	assert.Empty(t, albums.Next)
+	assert.Len(t, albums.Data, 10)
+
+	// Uma página com mais itens que o restante indica a próxima com o limite
+	results, err = service.GetCharts(ctx, driving.ChartParameters{
+		CatalogParameters: us,
+		Types:             []driving.SearchResultType{driving.AlbumsType},
+		Page:              driving.PageParameters{Limit: 5, Offset: 5},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/catalog/us/charts?chart=most-played&types=albums&offset=10&limit=5", results.Albums[0].Next)
+
+	_, err = service.GetCharts(ctx, driving.ChartParameters{
+		CatalogParameters: us,
+		Types:             []driving.SearchResultType{driving.ArtistsType},
+		Page:              driving.PageParameters{Limit: 10},
+	})
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err))
+}
+
+func TestChartService_CodesAreSharedWithMusicService(t *testing.T) {
+	codes := NewCodedProvider(newCatalog(3), DefaultMaxCodes)
+	charts := NewChartService(codes)
+	music := NewMusicService(codes)
+	ctx := context.Background()
+
+	results, err := charts.GetCharts(ctx, driving.ChartParameters{
+		CatalogParameters: us,
+		Types:             []driving.SearchResultType{driving.SongsType, driving.AlbumsType},
+		Page:              driving.PageParameters{Limit: driving.DefaultChartLimit},
+	})
+	require.NoError(t, err)
+
+	// Códigos vistos apenas nos rankings são encontrados pelos filtros
+	songs, err := music.GetSongsByISRC(ctx, us, []string{results.Songs[0].Data[2].Attributes.ISRC})
+	require.NoError(t, err)
+	require.Len(t, songs, 1)
+	assert.Equal(t, "song-2", songs[0].ID)
+
+	albums, err := music.GetAlbumsByUPC(ctx, us, []string{results.Albums[0].Data[1].Attributes.UPC})
+	require.NoError(t, err)
+	require.Len(t, albums, 1)
+	assert.Equal(t, "album-1", albums[0].ID)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"container/list"
+	"slices"
+)
+
+// DefaultMaxCodes é o número padrão de códigos ISRC ou UPC indexados
+const DefaultMaxCodes = 10000
+
+// codeIndex associa cada código aos IDs dos recursos emitidos com ele,
+// descartando os códigos usados há mais tempo quando o limite de entradas é
+// atingido. Não é seguro para uso concorrente.
+type codeIndex struct {
+	maxEntries int
+	order      *list.List // Mais recentes na frente
+	entries    map[string]*list.Element
+}
+
+type codeEntry struct {
+	code string
+	ids  []string
+}
+
+func newCodeIndex(maxEntries int) *codeIndex {
+	return &codeIndex{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get retorna uma cópia dos IDs associados ao código
+func (c *codeIndex) get(code string) []string {
+	elem, ok := c.entries[code]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return slices.Clone(elem.Value.(*codeEntry).ids)
+}
+
+// add associa o ID ao código, descartando o código usado há mais tempo se o
+// limite for excedido
+func (c *codeIndex) add(code, id string) {
+	if elem, ok := c.entries[code]; ok {
+		e := elem.Value.(*codeEntry)
+		if !slices.Contains(e.ids, id) {
+			e.ids = append(e.ids, id)
+		}
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[code] = c.order.PushFront(&codeEntry{code: code, ids: []string{id}})
+	if c.order.Len() > c.maxEntries {
+		back := c.order.Back()
+		c.order.Remove(back)
+		delete(c.entries, back.Value.(*codeEntry).code)
+	}
+}
+
+func (c *codeIndex) len() int {
+	return c.order.Len()
+}
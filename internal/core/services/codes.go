@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+)
+
+// CodedProvider decora um driven.MusicProvider atribuindo ISRC e UPC estáveis
+// às músicas e álbuns retornados e indexando esses códigos para que possam ser
+// usados nas buscas por filter[isrc] e filter[upc].
+// Códigos já preenchidos pelo provedor são mantidos.
+//
+// Os serviços que emitem músicas e álbuns devem compartilhar a mesma instância,
+// para que um código emitido por qualquer um deles possa ser buscado pelo filtro.
+//
+// Os códigos são hashes dos IDs e não podem ser revertidos, então um filtro só
+// encontra recursos emitidos desde a inicialização e cujo código ainda está no
+// índice em memória, limitado aos maxCodes usados mais recentemente por tipo.
+type CodedProvider struct {
+	driven.MusicProvider
+
+	mu          sync.Mutex
+	songsByISRC *codeIndex
+	albumsByUPC *codeIndex
+}
+
+// NewCodedProvider cria o decorador de códigos na frente do provedor informado,
+// indexando até maxCodes ISRCs e maxCodes UPCs (DefaultMaxCodes se não for positivo)
+func NewCodedProvider(provider driven.MusicProvider, maxCodes int) *CodedProvider {
+	if maxCodes <= 0 {
+		maxCodes = DefaultMaxCodes
+	}
+	return &CodedProvider{
+		MusicProvider: provider,
+		songsByISRC:   newCodeIndex(maxCodes),
+		albumsByUPC:   newCodeIndex(maxCodes),
+	}
+}
+
+func (p *CodedProvider) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	songs, err := p.MusicProvider.SearchSongs(ctx, language, term, limit, offset)
+	p.codeSongs(songs)
+	return songs, err
+}
+
+func (p *CodedProvider) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
+	albums, err := p.MusicProvider.SearchAlbums(ctx, language, term, limit, offset)
+	p.codeAlbums(albums)
+	return albums, err
+}
+
+func (p *CodedProvider) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	song, err := p.MusicProvider.GetSong(ctx, language, id)
+	if song != nil {
+		p.codeSong(song)
+	}
+	return song, err
+}
+
+func (p *CodedProvider) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	album, err := p.MusicProvider.GetAlbum(ctx, language, id)
+	if album != nil {
+		p.codeAlbum(album)
+	}
+	return album, err
+}
+
+func (p *CodedProvider) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	songs, err := p.MusicProvider.GetAlbumTracks(ctx, language, id)
+	p.codeSongs(songs)
+	return songs, err
+}
+
+func (p *CodedProvider) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	albums, err := p.MusicProvider.GetArtistAlbums(ctx, language, id, limit, offset)
+	p.codeAlbums(albums)
+	return albums, err
+}
+
+func (p *CodedProvider) GetAlbumWithTracks(ctx context.Context, language, id string) (*driven.AlbumWithTracks, error) {
+	result, err := p.MusicProvider.GetAlbumWithTracks(ctx, language, id)
+	if result != nil {
+		p.codeAlbum(&result.Album)
+		p.codeSongs(result.Tracks)
+	}
+	return result, err
+}
+
+func (p *CodedProvider) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	result, err := p.MusicProvider.GetArtistWithAlbums(ctx, language, id, limit)
+	if result != nil {
+		p.codeAlbums(result.Albums)
+	}
+	return result, err
+}
+
+func (p *CodedProvider) GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error) {
+	songs, err := p.MusicProvider.GetTopSongs(ctx, language, storefront, genre, limit, offset)
+	p.codeSongs(songs)
+	return songs, err
+}
+
+func (p *CodedProvider) GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error) {
+	albums, err := p.MusicProvider.GetTopAlbums(ctx, language, genre, limit, offset)
+	p.codeAlbums(albums)
+	return albums, err
+}
+
+// songIDsByISRC retorna os IDs das músicas já emitidas com o ISRC informado
+func (p *CodedProvider) songIDsByISRC(isrc string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.songsByISRC.get(isrc)
+}
+
+// albumIDsByUPC retorna os IDs dos álbuns já emitidos com o UPC informado
+func (p *CodedProvider) albumIDsByUPC(upc string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.albumsByUPC.get(upc)
+}
+
+func (p *CodedProvider) codeSongs(songs []domain.Song) {
+	for i := range songs {
+		p.codeSong(&songs[i])
+	}
+}
+
+func (p *CodedProvider) codeAlbums(albums []domain.Album) {
+	for i := range albums {
+		p.codeAlbum(&albums[i])
+	}
+}
+
+// codeSong atribui e indexa o ISRC da música
+func (p *CodedProvider) codeSong(song *domain.Song) {
+	if song.Attributes.ISRC == "" {
+		song.Attributes.ISRC = domain.ISRCFor(song.ID)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.songsByISRC.add(song.Attributes.ISRC, song.ID)
+}
+
+// codeAlbum atribui e indexa o UPC do álbum
+func (p *CodedProvider) codeAlbum(album *domain.Album) {
+	if album.Attributes.UPC == "" {
+		album.Attributes.UPC = domain.UPCFor(album.ID)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.albumsByUPC.add(album.Attributes.UPC, album.ID)
+}
+
+// codedProviderFor retorna o provedor se ele já for um *CodedProvider, ou o
+// decora com um novo
+func codedProviderFor(provider driven.MusicProvider) *CodedProvider {
+	if codes, ok := provider.(*CodedProvider); ok {
+		return codes
+	}
+	return NewCodedProvider(provider, DefaultMaxCodes)
+}
+
+func appendUnique(ids []string, id string) []string {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"fmt"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+type GenreService struct{}
+
+func NewGenreService() driving.GenreService {
+	return &GenreService{}
+}
+
+func (s *GenreService) ListTopGenres(catalog driving.CatalogParameters) []domain.Genre {
+	genres := domain.TopGenres()
+	genreHrefs(catalog.Storefront, genres)
+	return genres
+}
+
+func (s *GenreService) GetGenre(catalog driving.CatalogParameters, id string) (*domain.Genre, error) {
+	genre, ok := domain.FindGenre(id)
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("genre %s not found", id))
+	}
+	genre.Href = domain.CatalogHref(catalog.Storefront, "genres", genre.ID)
+	return genre, nil
+}
+
+func (s *GenreService) GetGenres(catalog driving.CatalogParameters, ids []string) []domain.Genre {
+	genres := []domain.Genre{}
+	for _, id := range ids {
+		if genre, ok := domain.FindGenre(id); ok {
+			genres = append(genres, *genre)
+		}
+	}
+	genreHrefs(catalog.Storefront, genres)
+	return genres
+}
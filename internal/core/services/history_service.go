@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// HeavyRotationWindow é o período de reprodução considerado na heavy rotation
+const HeavyRotationWindow = 30 * 24 * time.Hour
+
+// Hrefs das listas do histórico de reprodução
+const (
+	recentPlayedHref  = "/v1/me/recent/played"
+	recentTracksHref  = "/v1/me/recent/played/tracks"
+	heavyRotationHref = "/v1/me/history/heavy-rotation"
+)
+
+type HistoryService struct {
+	musicProvider driven.MusicProvider
+	plays         driven.PlayHistoryRepository
+	playlists     driven.PlaylistRepository
+	users         driving.UserService
+	now           func() time.Time
+}
+
+func NewHistoryService(musicProvider driven.MusicProvider, plays driven.PlayHistoryRepository, playlists driven.PlaylistRepository, users driving.UserService) driving.HistoryService {
+	return &HistoryService{
+		musicProvider: codedProviderFor(musicProvider),
+		plays:         plays,
+		playlists:     playlists,
+		users:         users,
+		now:           time.Now,
+	}
+}
+
+func (s *HistoryService) RecordPlays(ctx context.Context, userID string, plays []domain.PlayEvent) error {
+	if len(plays) == 0 {
+		return domain.InvalidParameter("", "at least one play event is required")
+	}
+	if _, err := s.users.GetUser(ctx, userID); err != nil {
+		return fmt.Errorf("error getting user %q: %w", userID, err)
+	}
+
+	// Validar todos os eventos antes de registrar qualquer um
+	now := s.now()
+	checked := make(map[string]bool)
+	events := make([]domain.PlayEvent, len(plays))
+	for i, play := range plays {
+		if play.PlayedAt.IsZero() {
+			play.PlayedAt = now
+		}
+		if play.PlayedAt.After(now) {
+			return domain.InvalidParameter("", "playedAt must not be in the future")
+		}
+		if play.SongID == "" {
+			return domain.InvalidParameter("", "songId is required")
+		}
+		if !checked["songs/"+play.SongID] {
+			if err := s.checkExists(ctx, "song", play.SongID, func() error {
+				_, err := s.musicProvider.GetSong(ctx, "", play.SongID)
+				return err
+			}); err != nil {
+				return err
+			}
+			checked["songs/"+play.SongID] = true
+		}
+		if play.Container != nil {
+			if err := s.checkContainer(ctx, userID, *play.Container, checked); err != nil {
+				return err
+			}
+		}
+		events[i] = play
+	}
+
+	if err := s.plays.AddPlays(ctx, userID, events); err != nil {
+		return fmt.Errorf("error recording plays: %w", err)
+	}
+	return nil
+}
+
+// checkContainer valida o tipo e a existência do container de um evento
+func (s *HistoryService) checkContainer(ctx context.Context, userID string, container domain.PlayContainer, checked map[string]bool) error {
+	if container.ID == "" {
+		return domain.InvalidParameter("", "container id is required")
+	}
+	key := container.Type + "/" + container.ID
+	if checked[key] {
+		return nil
+	}
+
+	var err error
+	switch container.Type {
+	case driving.PlayContainerAlbums:
+		err = s.checkExists(ctx, "album", container.ID, func() error {
+			_, err := s.musicProvider.GetAlbum(ctx, "", container.ID)
+			return err
+		})
+	case driving.PlayContainerPlaylists:
+		err = s.checkExists(ctx, "library playlist", container.ID, func() error {
+			_, err := s.playlists.GetPlaylist(ctx, userID, container.ID)
+			return err
+		})
+	case driving.PlayContainerStations:
+		// Estações não existem no catálogo do simulador e são aceitas como informadas
+	default:
+		err = domain.InvalidParameter("", fmt.Sprintf("container type %q is not supported (expected albums, playlists or stations)", container.Type))
+	}
+	if err == nil {
+		checked[key] = true
+	}
+	return err
+}
+
+// checkExists converte o domain.ErrNotFound de get em um erro de parâmetro inválido
+func (s *HistoryService) checkExists(ctx context.Context, kind, id string, get func() error) error {
+	err := get()
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.InvalidParameter("", fmt.Sprintf("%s %s not found", kind, id))
+	}
+	if err != nil {
+		return fmt.Errorf("error getting %s %q: %w", kind, id, err)
+	}
+	return nil
+}
+
+func (s *HistoryService) RecentlyPlayed(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[driving.PlayedResource], error) {
+	plays, err := s.plays.ListPlays(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing plays: %w", err)
+	}
+
+	var containers []domain.PlayContainer
+	seen := make(map[domain.PlayContainer]bool)
+	for _, play := range plays {
+		if play.Container == nil {
+			continue
+		}
+		key := containerKey(*play.Container)
+		if !seen[key] {
+			seen[key] = true
+			containers = append(containers, *play.Container)
+		}
+	}
+	return s.resolve(ctx, user, paginate(recentPlayedHref, containers, page))
+}
+
+func (s *HistoryService) RecentlyPlayedTracks(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[domain.Song], error) {
+	plays, err := s.plays.ListPlays(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing plays: %w", err)
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, play := range plays {
+		if !seen[play.SongID] {
+			seen[play.SongID] = true
+			ids = append(ids, play.SongID)
+		}
+	}
+
+	idPage := paginate(recentTracksHref, ids, page)
+	language := userLanguage(user)
+	songs := make([]domain.Song, 0, len(idPage.Data))
+	for _, id := range idPage.Data {
+		song, err := s.musicProvider.GetSong(ctx, language, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting song %q: %w", id, err)
+		}
+		songs = append(songs, *song)
+	}
+	songHrefs(user.Storefront, songs)
+	return &driving.Page[domain.Song]{Href: idPage.Href, Next: idPage.Next, Data: songs}, nil
+}
+
+func (s *HistoryService) HeavyRotation(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[driving.PlayedResource], error) {
+	plays, err := s.plays.ListPlays(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing plays: %w", err)
+	}
+
+	// Os eventos vêm do mais recente para o mais antigo, então a ordem de
+	// inserção já desempata os containers pela reprodução mais recente
+	since := s.now().Add(-HeavyRotationWindow)
+	var containers []domain.PlayContainer
+	counts := make(map[domain.PlayContainer]int)
+	for _, play := range plays {
+		if play.PlayedAt.Before(since) {
+			break
+		}
+		if play.Container == nil {
+			continue
+		}
+		key := containerKey(*play.Container)
+		if counts[key] == 0 {
+			containers = append(containers, *play.Container)
+		}
+		counts[key]++
+	}
+	slices.SortStableFunc(containers, func(a, b domain.PlayContainer) int {
+		return counts[containerKey(b)] - counts[containerKey(a)]
+	})
+	return s.resolve(ctx, user, paginate(heavyRotationHref, containers, page))
+}
+
+// resolve busca os recursos de uma página de containers. Containers que não
+// existem mais, como playlists removidas, são omitidos.
+func (s *HistoryService) resolve(ctx context.Context, user *domain.User, page *driving.Page[domain.PlayContainer]) (*driving.Page[driving.PlayedResource], error) {
+	language := userLanguage(user)
+	resources := make([]driving.PlayedResource, 0, len(page.Data))
+	for _, container := range page.Data {
+		var resource driving.PlayedResource
+		var err error
+		switch container.Type {
+		case driving.PlayContainerAlbums:
+			resource.Album, err = s.musicProvider.GetAlbum(ctx, language, container.ID)
+			if err == nil {
+				resource.Album.Href = domain.CatalogHref(user.Storefront, "albums", container.ID)
+			}
+		case driving.PlayContainerPlaylists:
+			resource.Playlist, err = s.playlists.GetPlaylist(ctx, user.ID, container.ID)
+		case driving.PlayContainerStations:
+			resource.Station = &domain.Station{ID: container.ID, Name: container.Name}
+		default:
+			continue
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s %q: %w", container.Type, container.ID, err)
+		}
+		resources = append(resources, resource)
+	}
+	return &driving.Page[driving.PlayedResource]{Href: page.Href, Next: page.Next, Data: resources}, nil
+}
+
+// containerKey identifica um container pelo tipo e ID, ignorando o nome
+func containerKey(container domain.PlayContainer) domain.PlayContainer {
+	return domain.PlayContainer{Type: container.Type, ID: container.ID}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/adapters/driven/memory"
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryService(t *testing.T) {
+	provider := &mockProvider{
+		songs:  []domain.Song{{ID: "song-1"}, {ID: "song-2"}, {ID: "song-3"}},
+		albums: []domain.Album{{ID: "album-1"}, {ID: "album-2"}},
+	}
+	playlists := memory.NewPlaylistRepository()
+	ctx := context.Background()
+	require.NoError(t, playlists.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.1", Name: "Mine"}))
+
+	now := time.Unix(1700000000, 0)
+	service := &HistoryService{
+		musicProvider: provider,
+		plays:         memory.NewPlayHistoryRepository(0),
+		playlists:     playlists,
+		users:         newUsers(t, "alice"),
+		now:           func() time.Time { return now },
+	}
+	user := &domain.User{ID: "alice", Storefront: "br"}
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	album1 := &domain.PlayContainer{Type: "albums", ID: "album-1"}
+	album2 := &domain.PlayContainer{Type: "albums", ID: "album-2"}
+	playlist := &domain.PlayContainer{Type: "playlists", ID: "p.1"}
+	station := &domain.PlayContainer{Type: "stations", ID: "ra.1", Name: "Radio"}
+
+	require.NoError(t, service.RecordPlays(ctx, "alice", []domain.PlayEvent{
+		// album-1 foi o mais tocado, mas há mais de 30 dias
+		{SongID: "song-1", Container: album1, PlayedAt: ago(40 * 24 * time.Hour)},
+		{SongID: "song-1", Container: album1, PlayedAt: ago(39 * 24 * time.Hour)},
+		{SongID: "song-1", Container: album1, PlayedAt: ago(38 * 24 * time.Hour)},
+		{SongID: "song-2", Container: album2, PlayedAt: ago(5 * time.Hour)},
+		{SongID: "song-2", Container: album2, PlayedAt: ago(4 * time.Hour)},
+		{SongID: "song-3", Container: playlist, PlayedAt: ago(3 * time.Hour)},
+		{SongID: "song-1", Container: station, PlayedAt: ago(2 * time.Hour)},
+		{SongID: "song-2", PlayedAt: ago(time.Hour)},
+	}))
+
+	recent, err := service.RecentlyPlayed(ctx, user, driving.PageParameters{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stations/ra.1", "playlists/p.1", "albums/album-2", "albums/album-1"}, resourceKeys(recent.Data))
+	assert.Equal(t, "Radio", recent.Data[0].Station.Name)
+	assert.Equal(t, "/v1/catalog/br/albums/album-2", recent.Data[2].Album.Href)
+
+	recent, err = service.RecentlyPlayed(ctx, user, driving.PageParameters{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, recent.Data, 2)
+	assert.Equal(t, "/v1/me/recent/played?offset=2&limit=2", recent.Next)
+
+	tracks, err := service.RecentlyPlayedTracks(ctx, user, driving.PageParameters{Limit: 10})
+	require.NoError(t, err)
+	var ids []string
+	for _, song := range tracks.Data {
+		ids = append(ids, song.ID)
+	}
+	assert.Equal(t, []string{"song-2", "song-1", "song-3"}, ids)
+	assert.Equal(t, "/v1/catalog/br/songs/song-2", tracks.Data[0].Href)
+
+	rotation, err := service.HeavyRotation(ctx, user, driving.PageParameters{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"albums/album-2", "stations/ra.1", "playlists/p.1"}, resourceKeys(rotation.Data))
+
+	empty, err := service.RecentlyPlayed(ctx, &domain.User{ID: "bob"}, driving.PageParameters{Limit: 10})
+	require.NoError(t, err)
+	assert.NotNil(t, empty.Data)
+	assert.Empty(t, empty.Data)
+}
+
+func TestHistoryService_RecordPlaysValidation(t *testing.T) {
+	provider := &mockProvider{songs: []domain.Song{{ID: "song-1"}}, albums: []domain.Album{{ID: "album-1"}}}
+	plays := memory.NewPlayHistoryRepository(0)
+	service := NewHistoryService(provider, plays, memory.NewPlaylistRepository(), newUsers(t, "alice"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		plays []domain.PlayEvent
+	}{
+		{"No events", nil},
+		{"Missing song", []domain.PlayEvent{{}}},
+		{"Unknown song", []domain.PlayEvent{{SongID: "unknown"}}},
+		{"Unknown album", []domain.PlayEvent{{SongID: "song-1", Container: &domain.PlayContainer{Type: "albums", ID: "unknown"}}}},
+		{"Playlist of another user", []domain.PlayEvent{{SongID: "song-1", Container: &domain.PlayContainer{Type: "playlists", ID: "p.1"}}}},
+		{"Unknown container type", []domain.PlayEvent{{SongID: "song-1", Container: &domain.PlayContainer{Type: "videos", ID: "v.1"}}}},
+		{"Future event", []domain.PlayEvent{{SongID: "song-1", PlayedAt: time.Now().Add(time.Hour)}}},
+		{"One invalid event", []domain.PlayEvent{{SongID: "song-1"}, {SongID: "unknown"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.RecordPlays(ctx, "alice", tt.plays)
+			assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err))
+		})
+	}
+
+	recorded, err := plays.ListPlays(ctx, "alice")
+	require.NoError(t, err)
+	assert.Empty(t, recorded, "invalid batches record nothing")
+
+	err = service.RecordPlays(ctx, "bob", []domain.PlayEvent{{SongID: "song-1"}})
+	assert.Equal(t, domain.KindNotFound, domain.KindOf(err), "plays of unknown users are rejected")
+
+	require.NoError(t, service.RecordPlays(ctx, "alice", []domain.PlayEvent{{SongID: "song-1"}}))
+	recorded, err = plays.ListPlays(ctx, "alice")
+	require.NoError(t, err)
+	if assert.Len(t, recorded, 1) {
+		assert.False(t, recorded[0].PlayedAt.IsZero(), "events without playedAt are played now")
+	}
+}
+
+// newUsers cria um serviço de usuários com os usuários informados
+func newUsers(t *testing.T, ids ...string) driving.UserService {
+	t.Helper()
+	users := NewUserService()
+	for _, id := range ids {
+		_, err := users.IssueToken(context.Background(), driving.IssueUserTokenParameters{UserID: id})
+		require.NoError(t, err)
+	}
+	return users
+}
+
+func resourceKeys(resources []driving.PlayedResource) []string {
+	keys := make([]string, len(resources))
+	for i, resource := range resources {
+		switch {
+		case resource.Album != nil:
+			keys[i] = "albums/" + resource.Album.ID
+		case resource.Playlist != nil:
+			keys[i] = "playlists/" + resource.Playlist.ID
+		case resource.Station != nil:
+			keys[i] = "stations/" + resource.Station.ID
+		}
+	}
+	return keys
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"applemusic-api-simulator/internal/core/domain"
+)
+
+// Os hrefs dos recursos dependem do storefront da requisição, por isso são
+// atribuídos pelo serviço e não pelos provedores.
+
+func songHrefs(storefront string, songs []domain.Song) {
+	for i := range songs {
+		songs[i].Href = domain.CatalogHref(storefront, "songs", songs[i].ID)
+	}
+}
+
+func albumHrefs(storefront string, albums []domain.Album) {
+	for i := range albums {
+		albums[i].Href = domain.CatalogHref(storefront, "albums", albums[i].ID)
+	}
+}
+
+func artistHrefs(storefront string, artists []domain.Artist) {
+	for i := range artists {
+		artists[i].Href = domain.CatalogHref(storefront, "artists", artists[i].ID)
+	}
+}
+
+func genreHrefs(storefront string, genres []domain.Genre) {
+	for i := range genres {
+		genres[i].Href = domain.CatalogHref(storefront, "genres", genres[i].ID)
+	}
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// Hrefs das listas de recursos da biblioteca
+const (
+	libraryPlaylistsHref = "/v1/me/library/playlists"
+	librarySongsHref     = "/v1/me/library/songs"
+	libraryAlbumsHref    = "/v1/me/library/albums"
+	libraryArtistsHref   = "/v1/me/library/artists"
+)
+
+type LibraryService struct {
+	musicProvider driven.MusicProvider
+	playlists     driven.PlaylistRepository
+	library       driven.LibraryRepository
+	now           func() time.Time
+}
+
+func NewLibraryService(musicProvider driven.MusicProvider, playlists driven.PlaylistRepository, library driven.LibraryRepository) driving.LibraryService {
+	return &LibraryService{
+		musicProvider: codedProviderFor(musicProvider),
+		playlists:     playlists,
+		library:       library,
+		now:           time.Now,
+	}
+}
+
+func (s *LibraryService) AddToLibrary(ctx context.Context, user *domain.User, params driving.AddToLibraryParameters) error {
+	if len(params.SongIDs) == 0 && len(params.AlbumIDs) == 0 {
+		return domain.InvalidParameter("ids", "at least one of ids[songs] or ids[albums] is required")
+	}
+
+	// Buscar todos os recursos antes de alterar a biblioteca
+	language := userLanguage(user)
+	songs := make([]*domain.Song, 0, len(params.SongIDs))
+	for _, id := range params.SongIDs {
+		song, err := s.musicProvider.GetSong(ctx, language, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.InvalidParameter("ids[songs]", fmt.Sprintf("song %s not found in the catalog", id))
+		}
+		if err != nil {
+			return fmt.Errorf("error getting song %q: %w", id, err)
+		}
+		songs = append(songs, song)
+	}
+	albums := make([]*domain.Album, 0, len(params.AlbumIDs))
+	for _, id := range params.AlbumIDs {
+		album, err := s.musicProvider.GetAlbum(ctx, language, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.InvalidParameter("ids[albums]", fmt.Sprintf("album %s not found in the catalog", id))
+		}
+		if err != nil {
+			return fmt.Errorf("error getting album %q: %w", id, err)
+		}
+		albums = append(albums, album)
+	}
+
+	now := s.now()
+	var artists []string
+	for _, song := range songs {
+		if _, err := s.library.AddSong(ctx, user.ID, domain.LibrarySong{ID: newLibraryID("i."), DateAdded: now, Song: *song}); err != nil {
+			return fmt.Errorf("error adding song %q to the library: %w", song.ID, err)
+		}
+		artists = append(artists, song.Attributes.ArtistName)
+	}
+	for _, album := range albums {
+		if _, err := s.library.AddAlbum(ctx, user.ID, domain.LibraryAlbum{ID: newLibraryID("l."), DateAdded: now, Album: *album}); err != nil {
+			return fmt.Errorf("error adding album %q to the library: %w", album.ID, err)
+		}
+		artists = append(artists, album.Attributes.ArtistName)
+	}
+	for _, name := range artists {
+		if name == "" {
+			continue
+		}
+		if _, err := s.library.AddArtist(ctx, user.ID, domain.LibraryArtist{ID: newLibraryID("r."), DateAdded: now, Name: name}); err != nil {
+			return fmt.Errorf("error adding artist %q to the library: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *LibraryService) ListSongs(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[domain.LibrarySong], error) {
+	songs, err := s.library.ListSongs(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing library songs: %w", err)
+	}
+	result := paginate(librarySongsHref, songs, page)
+	for i := range result.Data {
+		result.Data[i].Song.Href = domain.CatalogHref(user.Storefront, "songs", result.Data[i].Song.ID)
+	}
+	return result, nil
+}
+
+func (s *LibraryService) GetSong(ctx context.Context, user *domain.User, id string) (*domain.LibrarySong, error) {
+	song, err := s.library.GetSong(ctx, user.ID, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting library song %q: %w", id, err)
+	}
+	song.Song.Href = domain.CatalogHref(user.Storefront, "songs", song.Song.ID)
+	return song, nil
+}
+
+func (s *LibraryService) ListAlbums(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[domain.LibraryAlbum], error) {
+	albums, err := s.library.ListAlbums(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing library albums: %w", err)
+	}
+	result := paginate(libraryAlbumsHref, albums, page)
+	for i := range result.Data {
+		result.Data[i].Album.Href = domain.CatalogHref(user.Storefront, "albums", result.Data[i].Album.ID)
+	}
+	return result, nil
+}
+
+func (s *LibraryService) GetAlbum(ctx context.Context, user *domain.User, id string) (*domain.LibraryAlbum, error) {
+	album, err := s.library.GetAlbum(ctx, user.ID, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting library album %q: %w", id, err)
+	}
+	album.Album.Href = domain.CatalogHref(user.Storefront, "albums", album.Album.ID)
+	return album, nil
+}
+
+func (s *LibraryService) ListArtists(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[domain.LibraryArtist], error) {
+	artists, err := s.library.ListArtists(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing library artists: %w", err)
+	}
+	return paginate(libraryArtistsHref, artists, page), nil
+}
+
+func (s *LibraryService) GetArtist(ctx context.Context, user *domain.User, id string) (*domain.LibraryArtist, error) {
+	artist, err := s.library.GetArtist(ctx, user.ID, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting library artist %q: %w", id, err)
+	}
+	return artist, nil
+}
+
+func (s *LibraryService) ListPlaylists(ctx context.Context, user *domain.User, page driving.PageParameters) (*driving.Page[domain.Playlist], error) {
+	playlists, err := s.playlists.ListPlaylists(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing playlists: %w", err)
+	}
+	return paginate(libraryPlaylistsHref, playlists, page), nil
+}
+
+func (s *LibraryService) CreatePlaylist(ctx context.Context, user *domain.User, params driving.CreatePlaylistParameters) (*domain.Playlist, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, domain.InvalidParameter("", "playlist name is required")
+	}
+	tracks, err := s.tracks(ctx, user, params.SongIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	playlist := domain.Playlist{
+		ID:               newLibraryID("p."),
+		Name:             name,
+		Description:      params.Description,
+		DateAdded:        now,
+		LastModifiedDate: now,
+	}
+	for _, track := range tracks {
+		if err := playlist.AddTrack(track); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.playlists.CreatePlaylist(ctx, user.ID, playlist); err != nil {
+		return nil, fmt.Errorf("error creating playlist: %w", err)
+	}
+	return &playlist, nil
+}
+
+func (s *LibraryService) GetPlaylist(ctx context.Context, user *domain.User, id string) (*domain.Playlist, error) {
+	playlist, err := s.playlists.GetPlaylist(ctx, user.ID, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist %q: %w", id, err)
+	}
+	return playlist, nil
+}
+
+func (s *LibraryService) GetPlaylistTracks(ctx context.Context, user *domain.User, id string, page driving.PageParameters) (*driving.Page[domain.Track], error) {
+	playlist, err := s.GetPlaylist(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(domain.LibraryHref("playlists", playlist.ID)+"/tracks", playlist.Tracks, page), nil
+}
+
+func (s *LibraryService) AddPlaylistTracks(ctx context.Context, user *domain.User, id string, songIDs []string) (*domain.Playlist, error) {
+	if len(songIDs) == 0 {
+		return nil, domain.InvalidParameter("", "at least one track is required")
+	}
+	// Buscar as músicas antes de alterar a playlist, fora da atualização atômica
+	tracks, err := s.tracks(ctx, user, songIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.playlists.UpdatePlaylist(ctx, user.ID, id, func(p *domain.Playlist) error {
+		for _, track := range tracks {
+			if err := p.AddTrack(track); err != nil {
+				return err
+			}
+		}
+		p.LastModifiedDate = s.now()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding tracks to playlist %q: %w", id, err)
+	}
+	return playlist, nil
+}
+
+func (s *LibraryService) RemovePlaylistTrack(ctx context.Context, user *domain.User, id, trackID string) error {
+	_, err := s.playlists.UpdatePlaylist(ctx, user.ID, id, func(p *domain.Playlist) error {
+		if !p.RemoveTrack(trackID) {
+			return domain.NotFound(fmt.Sprintf("track %s not found in playlist %s", trackID, id))
+		}
+		p.LastModifiedDate = s.now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error removing track from playlist %q: %w", id, err)
+	}
+	return nil
+}
+
+// tracks busca no catálogo as músicas informadas e as converte em faixas de playlist
+func (s *LibraryService) tracks(ctx context.Context, user *domain.User, songIDs []string) ([]domain.Track, error) {
+	language := userLanguage(user)
+	tracks := make([]domain.Track, 0, len(songIDs))
+	for _, id := range songIDs {
+		song, err := s.musicProvider.GetSong(ctx, language, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.InvalidParameter("", fmt.Sprintf("song %s not found in the catalog", id))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting song %q: %w", id, err)
+		}
+		tracks = append(tracks, trackFromSong(song))
+	}
+	return tracks, nil
+}
+
+// userLanguage retorna o idioma padrão do storefront do usuário
+func userLanguage(user *domain.User) string {
+	if storefront, ok := domain.FindStorefront(user.Storefront); ok {
+		return storefront.Attributes.DefaultLanguageTag
+	}
+	return ""
+}
+
+// paginate retorna a página de uma lista completa de recursos
+func paginate[T any](href string, items []T, page driving.PageParameters) *driving.Page[T] {
+	if page.Offset >= len(items) {
+		return &driving.Page[T]{Href: href, Data: []T{}}
+	}
+	end := min(page.Offset+page.Limit, len(items))
+	return &driving.Page[T]{
+		Href: href,
+		Next: nextHref(href, page, end < len(items)),
+		Data: items[page.Offset:end],
+	}
+}
+
+// trackFromSong converte uma música do catálogo em uma faixa de playlist
+func trackFromSong(song *domain.Song) domain.Track {
+	return domain.Track{
+		ID:       song.ID,
+		Title:    song.Attributes.Name,
+		Artist:   song.Attributes.ArtistName,
+		Duration: song.Attributes.DurationInMillis,
+		CoverURL: song.Attributes.Artwork.URL,
+	}
+}
+
+// libraryIDAlphabet são os caracteres dos IDs de recursos da biblioteca
+const libraryIDAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// newLibraryID gera um ID de recurso da biblioteca com o prefixo do tipo, como
+// os IDs "p.ldvAr5KuY8E3VB" das playlists da Apple
+func newLibraryID(prefix string) string {
+	b := make([]byte, 14)
+	rand.Read(b)
+	for i := range b {
+		b[i] = libraryIDAlphabet[int(b[i])%len(libraryIDAlphabet)]
+	}
+	return prefix + string(b)
+}
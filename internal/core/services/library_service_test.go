@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"applemusic-api-simulator/internal/adapters/driven/memory"
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryService_Playlists(t *testing.T) {
+	provider := &mockProvider{songs: []domain.Song{
+		{ID: "song-1", Attributes: domain.SongAttributes{Name: "One", ArtistName: "Artist", DurationInMillis: 1000}},
+		{ID: "song-2", Attributes: domain.SongAttributes{Name: "Two", ArtistName: "Artist"}},
+	}}
+	service := NewLibraryService(provider, memory.NewPlaylistRepository(), memory.NewLibraryRepository())
+	ctx := context.Background()
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	playlist, err := service.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{Name: " Road Trip ", SongIDs: []string{"song-1"}})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(playlist.ID, "p."), playlist.ID)
+	assert.Equal(t, "Road Trip", playlist.Name)
+	assert.Equal(t, []domain.Track{{ID: "song-1", Title: "One", Artist: "Artist", Duration: 1000}}, playlist.Tracks)
+
+	_, err = service.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{Name: " "})
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err), "name is required")
+	_, err = service.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{Name: "Unknown", SongIDs: []string{"unknown"}})
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err), "songs must exist")
+
+	playlist, err = service.AddPlaylistTracks(ctx, user, playlist.ID, []string{"song-2", "song-1"})
+	require.NoError(t, err)
+	assert.Len(t, playlist.Tracks, 3)
+
+	// Uma música inexistente impede a adição de todas as outras
+	_, err = service.AddPlaylistTracks(ctx, user, playlist.ID, []string{"song-2", "unknown"})
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err))
+
+	require.NoError(t, service.RemovePlaylistTrack(ctx, user, playlist.ID, "song-1"))
+	playlist, err = service.GetPlaylist(ctx, user, playlist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"song-2"}, trackIDs(playlist.Tracks))
+
+	err = service.RemovePlaylistTrack(ctx, user, playlist.ID, "song-1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+
+	_, err = service.GetPlaylist(ctx, &domain.User{ID: "bob"}, playlist.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "playlists belong to their user")
+}
+
+func TestLibraryService_PlaylistTrackLimit(t *testing.T) {
+	provider := &mockProvider{songs: []domain.Song{{ID: "song-1"}}}
+	service := NewLibraryService(provider, memory.NewPlaylistRepository(), memory.NewLibraryRepository())
+	ctx := context.Background()
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	full := make([]string, domain.MaxTracksPerPlaylist)
+	for i := range full {
+		full[i] = "song-1"
+	}
+	_, err := service.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{Name: "Too long", SongIDs: append(full, "song-1")})
+	assert.True(t, errors.Is(err, domain.ErrPlaylistFull))
+
+	playlist, err := service.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{Name: "Full", SongIDs: full[1:]})
+	require.NoError(t, err)
+
+	// Adições que ultrapassam o limite não adicionam nenhuma faixa
+	_, err = service.AddPlaylistTracks(ctx, user, playlist.ID, []string{"song-1", "song-1"})
+	assert.True(t, errors.Is(err, domain.ErrPlaylistFull))
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err))
+	playlist, err = service.AddPlaylistTracks(ctx, user, playlist.ID, []string{"song-1"})
+	require.NoError(t, err)
+	assert.Len(t, playlist.Tracks, domain.MaxTracksPerPlaylist)
+}
+
+func TestLibraryService_ListPlaylists(t *testing.T) {
+	service := NewLibraryService(&mockProvider{}, memory.NewPlaylistRepository(), memory.NewLibraryRepository())
+	ctx := context.Background()
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	for i := 0; i < 3; i++ {
+		_, err := service.CreatePlaylist(ctx, user, driving.CreatePlaylistParameters{Name: fmt.Sprintf("Playlist %d", i)})
+		require.NoError(t, err)
+	}
+
+	page, err := service.ListPlaylists(ctx, user, driving.PageParameters{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.Equal(t, "Playlist 0", page.Data[0].Name)
+	assert.Equal(t, "/v1/me/library/playlists?offset=2&limit=2", page.Next)
+
+	page, err = service.ListPlaylists(ctx, user, driving.PageParameters{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Empty(t, page.Next)
+
+	page, err = service.ListPlaylists(ctx, &domain.User{ID: "bob"}, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	assert.NotNil(t, page.Data)
+	assert.Empty(t, page.Data)
+}
+
+func trackIDs(tracks []domain.Track) []string {
+	ids := make([]string, len(tracks))
+	for i, track := range tracks {
+		ids[i] = track.ID
+	}
+	return ids
+}
+
+func TestLibraryService_AddToLibrary(t *testing.T) {
+	provider := &mockProvider{
+		songs: []domain.Song{
+			{ID: "song-1", Attributes: domain.SongAttributes{Name: "One", ArtistName: "Artist"}},
+			{ID: "song-2", Attributes: domain.SongAttributes{Name: "Two", ArtistName: "Other Artist"}},
+		},
+		albums: []domain.Album{{ID: "album-1", Attributes: domain.AlbumAttributes{Name: "Album", ArtistName: "artist"}}},
+	}
+	service := NewLibraryService(provider, memory.NewPlaylistRepository(), memory.NewLibraryRepository())
+	ctx := context.Background()
+	user := &domain.User{ID: "alice", Storefront: "br"}
+
+	err := service.AddToLibrary(ctx, user, driving.AddToLibraryParameters{SongIDs: []string{"song-1"}, AlbumIDs: []string{"album-1"}})
+	require.NoError(t, err)
+	// Adicionar de novo não duplica os itens
+	err = service.AddToLibrary(ctx, user, driving.AddToLibraryParameters{SongIDs: []string{"song-1", "song-2"}})
+	require.NoError(t, err)
+
+	songs, err := service.ListSongs(ctx, user, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	if assert.Len(t, songs.Data, 2) {
+		assert.True(t, strings.HasPrefix(songs.Data[0].ID, "i."), songs.Data[0].ID)
+		assert.Equal(t, "song-1", songs.Data[0].Song.ID)
+		assert.Equal(t, "/v1/catalog/br/songs/song-1", songs.Data[0].Song.Href)
+	}
+
+	song, err := service.GetSong(ctx, user, songs.Data[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "song-2", song.Song.ID)
+	assert.Equal(t, "/v1/catalog/br/songs/song-2", song.Song.Href)
+
+	albums, err := service.ListAlbums(ctx, user, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	if assert.Len(t, albums.Data, 1) {
+		assert.True(t, strings.HasPrefix(albums.Data[0].ID, "l."), albums.Data[0].ID)
+		assert.Equal(t, "/v1/catalog/br/albums/album-1", albums.Data[0].Album.Href)
+	}
+
+	// Os artistas vêm das músicas e álbuns, sem diferenciar maiúsculas
+	artists, err := service.ListArtists(ctx, user, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	var names []string
+	for _, artist := range artists.Data {
+		assert.True(t, strings.HasPrefix(artist.ID, "r."), artist.ID)
+		names = append(names, artist.Name)
+	}
+	assert.Equal(t, []string{"Artist", "Other Artist"}, names)
+
+	// Um recurso inexistente impede a adição dos demais
+	other := &domain.User{ID: "bob", Storefront: "us"}
+	err = service.AddToLibrary(ctx, other, driving.AddToLibraryParameters{SongIDs: []string{"song-1"}, AlbumIDs: []string{"unknown"}})
+	assert.Equal(t, "ids[albums]", domain.ParameterOf(err))
+	songs, err = service.ListSongs(ctx, other, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	assert.Empty(t, songs.Data)
+
+	err = service.AddToLibrary(ctx, other, driving.AddToLibraryParameters{})
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err))
+	_, err = service.GetSong(ctx, other, song.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestLibraryService_CodesAreSharedWithMusicService(t *testing.T) {
+	codes := NewCodedProvider(newCatalog(3), DefaultMaxCodes)
+	library := NewLibraryService(codes, memory.NewPlaylistRepository(), memory.NewLibraryRepository())
+	music := NewMusicService(codes)
+	ctx := context.Background()
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	require.NoError(t, library.AddToLibrary(ctx, user, driving.AddToLibraryParameters{SongIDs: []string{"song-1"}, AlbumIDs: []string{"album-2"}}))
+	songs, err := library.ListSongs(ctx, user, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	require.Len(t, songs.Data, 1)
+	albums, err := library.ListAlbums(ctx, user, driving.PageParameters{Limit: 25})
+	require.NoError(t, err)
+	require.Len(t, albums.Data, 1)
+
+	// Os dados do catálogo na biblioteca têm códigos, encontrados pelos filtros
+	isrc := songs.Data[0].Song.Attributes.ISRC
+	require.NotEmpty(t, isrc)
+	found, err := music.GetSongsByISRC(ctx, us, []string{isrc})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "song-1", found[0].ID)
+
+	upc := albums.Data[0].Album.Attributes.UPC
+	require.NotEmpty(t, upc)
+	foundAlbums, err := music.GetAlbumsByUPC(ctx, us, []string{upc})
+	require.NoError(t, err)
+	require.Len(t, foundAlbums, 1)
+	assert.Equal(t, "album-2", foundAlbums[0].ID)
+}
@@ -1,62 +1,269 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
+	"applemusic-api-simulator/internal/core/domain"
 	"applemusic-api-simulator/internal/core/ports/driven"
 	"applemusic-api-simulator/internal/core/ports/driving"
 )
 
 type MusicService struct {
 	musicProvider driven.MusicProvider
+	codes         *CodedProvider
+	options       options
 }
 
-func NewMusicService(musicProvider driven.MusicProvider) driving.MusicService {
+// NewMusicService cria o serviço de música. Se o provedor for um *CodedProvider
+// os códigos ISRC e UPC são indexados nele, senão em um índice próprio do serviço.
+func NewMusicService(musicProvider driven.MusicProvider, opts ...Option) driving.MusicService {
+	codes := codedProviderFor(musicProvider)
 	return &MusicService{
-		musicProvider: musicProvider,
+		musicProvider: codes,
+		codes:         codes,
+		options:       newOptions(opts),
 	}
 }
 
-func (s *MusicService) Search(params driving.SearchParameters) (*driving.SearchResults, error) {
+// Search executa a busca de cada tipo solicitado de forma concorrente, cada uma
+// com o seu próprio prazo. Falhas de um tipo não impedem o retorno dos demais.
+func (s *MusicService) Search(ctx context.Context, params driving.SearchParameters) (*driving.SearchResults, error) {
+	// O gênero raiz inclui todos os resultados, mesmo sem gêneros atribuídos
+	if !s.options.genreFilter && params.Genre != "" && params.Genre != domain.MusicGenreID {
+		return nil, domain.InvalidParameter("genre", "genre filter is not supported by the music provider")
+	}
+
 	results := &driving.SearchResults{}
+	types := uniqueTypes(params.Types)
+	errs := make([]error, len(types))
 
-	// Para cada tipo solicitado, realizar a busca específica
-	for _, searchType := range params.Types {
-		switch searchType {
-		case driving.SongsType:
-			songs, err := s.musicProvider.SearchSongs(params.Term, params.Limit, params.Offset)
-			if err != nil {
-				return nil, fmt.Errorf("error searching songs: %w", err)
-			}
-			// Garantir que não exceda o limite
-			if len(songs) > params.Limit {
-				songs = songs[:params.Limit]
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.options.maxConcurrentSearches)
+	for i, searchType := range types {
+		wg.Add(1)
+		go func(i int, searchType driving.SearchResultType) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
 			}
-			results.Songs = songs
+			defer func() { <-sem }()
 
-		case driving.AlbumsType:
-			albums, err := s.musicProvider.SearchAlbums(params.Term, params.Limit, params.Offset)
-			if err != nil {
-				return nil, fmt.Errorf("error searching albums: %w", err)
-			}
-			// Garantir que não exceda o limite
-			if len(albums) > params.Limit {
-				albums = albums[:params.Limit]
-			}
-			results.Albums = albums
+			typeCtx, cancel := context.WithTimeout(ctx, s.options.searchTimeout)
+			defer cancel()
+			errs[i] = s.searchType(typeCtx, params, searchType, results)
+		}(i, searchType)
+	}
+	wg.Wait()
 
-		case driving.ArtistsType:
-			artists, err := s.musicProvider.SearchArtists(params.Term, params.Limit, params.Offset)
-			if err != nil {
-				return nil, fmt.Errorf("error searching artists: %w", err)
-			}
-			// Garantir que não exceda o limite
-			if len(artists) > params.Limit {
-				artists = artists[:params.Limit]
-			}
-			results.Artists = artists
+	for i, err := range errs {
+		if err != nil {
+			results.Failures = append(results.Failures, driving.SearchFailure{Type: types[i], Err: err})
 		}
 	}
-
+	if len(types) > 0 && len(results.Failures) == len(types) {
+		return nil, results.Failures[0].Err
+	}
 	return results, nil
 }
+
+// searchType busca um único tipo e preenche o campo correspondente de results.
+// Cada tipo escreve em um campo diferente, então as buscas podem ser concorrentes.
+func (s *MusicService) searchType(ctx context.Context, params driving.SearchParameters, searchType driving.SearchResultType, results *driving.SearchResults) error {
+	switch searchType {
+	case driving.SongsType:
+		songs, err := s.musicProvider.SearchSongs(ctx, params.Language, params.Term, params.Limit, params.Offset)
+		if err != nil {
+			return fmt.Errorf("error searching songs: %w", err)
+		}
+		songs = withGenre(songs, params.Genre, func(r domain.Song) []string { return r.Attributes.GenreNames })
+		// Garantir que não exceda o limite
+		if len(songs) > params.Limit {
+			songs = songs[:params.Limit]
+		}
+		songHrefs(params.Storefront, songs)
+		results.Songs = songs
+
+	case driving.AlbumsType:
+		albums, err := s.musicProvider.SearchAlbums(ctx, params.Language, params.Term, params.Limit, params.Offset)
+		if err != nil {
+			return fmt.Errorf("error searching albums: %w", err)
+		}
+		albums = withGenre(albums, params.Genre, func(r domain.Album) []string { return r.Attributes.GenreNames })
+		// Garantir que não exceda o limite
+		if len(albums) > params.Limit {
+			albums = albums[:params.Limit]
+		}
+		albumHrefs(params.Storefront, albums)
+		results.Albums = albums
+
+	case driving.ArtistsType:
+		artists, err := s.musicProvider.SearchArtists(ctx, params.Language, params.Term, params.Limit, params.Offset)
+		if err != nil {
+			return fmt.Errorf("error searching artists: %w", err)
+		}
+		artists = withGenre(artists, params.Genre, func(r domain.Artist) []string { return r.Attributes.GenreNames })
+		// Garantir que não exceda o limite
+		if len(artists) > params.Limit {
+			artists = artists[:params.Limit]
+		}
+		artistHrefs(params.Storefront, artists)
+		results.Artists = artists
+	}
+	return nil
+}
+
+// withGenre mantém apenas os resultados do gênero informado ou dos seus
+// subgêneros, ou todos se genre for vazio
+func withGenre[T any](results []T, genre string, genreNames func(T) []string) []T {
+	if genre == "" {
+		return results
+	}
+	filtered := make([]T, 0, len(results))
+	for _, r := range results {
+		if domain.HasGenre(genreNames(r), genre) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// uniqueTypes remove tipos repetidos, mantendo a ordem em que foram solicitados
+func uniqueTypes(types []driving.SearchResultType) []driving.SearchResultType {
+	var unique []driving.SearchResultType
+	seen := make(map[driving.SearchResultType]bool)
+	for _, t := range types {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+func (s *MusicService) GetSong(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Song, error) {
+	song, err := s.musicProvider.GetSong(ctx, catalog.Language, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting song %q: %w", id, err)
+	}
+	song.Href = domain.CatalogHref(catalog.Storefront, "songs", song.ID)
+	return song, nil
+}
+
+func (s *MusicService) GetAlbum(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Album, error) {
+	// A primeira página de faixas, incluída como relacionamento, vem da mesma consulta
+	result, err := s.musicProvider.GetAlbumWithTracks(ctx, catalog.Language, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting album %q: %w", id, err)
+	}
+	album := &result.Album
+	album.Href = domain.CatalogHref(catalog.Storefront, "albums", album.ID)
+
+	tracks := albumTracksPage(catalog, id, result.Tracks, driving.PageParameters{Limit: driving.DefaultPageLimit})
+	album.Relationships = &domain.AlbumRelationships{
+		Tracks: domain.SongsRelationship{
+			Href: tracks.Href,
+			Next: tracks.Next,
+			Data: tracks.Data,
+		},
+	}
+	return album, nil
+}
+
+func (s *MusicService) GetArtist(ctx context.Context, catalog driving.CatalogParameters, id string) (*domain.Artist, error) {
+	// A primeira página de álbuns, incluída como relacionamento, vem da mesma consulta.
+	// Buscar um item a mais para saber se existe uma próxima página.
+	page := driving.PageParameters{Limit: driving.DefaultPageLimit}
+	result, err := s.musicProvider.GetArtistWithAlbums(ctx, catalog.Language, id, page.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("error getting artist %q: %w", id, err)
+	}
+	artist := &result.Artist
+	artist.Href = domain.CatalogHref(catalog.Storefront, "artists", artist.ID)
+
+	albums := artistAlbumsPage(catalog, id, result.Albums, page)
+	refs := make([]domain.ResourceRef, 0, len(albums.Data))
+	for _, album := range albums.Data {
+		refs = append(refs, domain.ResourceRef{ID: album.ID, Type: album.Type, Href: album.Href})
+	}
+	artist.Relationships = &domain.ArtistRelationships{
+		Albums: domain.AlbumsRelationship{
+			Href: albums.Href,
+			Next: albums.Next,
+			Data: refs,
+		},
+	}
+	return artist, nil
+}
+
+func (s *MusicService) GetAlbumTracks(ctx context.Context, catalog driving.CatalogParameters, id string, page driving.PageParameters) (*driving.Page[domain.Song], error) {
+	tracks, err := s.musicProvider.GetAlbumTracks(ctx, catalog.Language, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tracks of album %q: %w", id, err)
+	}
+	return albumTracksPage(catalog, id, tracks, page), nil
+}
+
+func (s *MusicService) GetArtistAlbums(ctx context.Context, catalog driving.CatalogParameters, id string, page driving.PageParameters) (*driving.Page[domain.Album], error) {
+	// Buscar um item a mais para saber se existe uma próxima página
+	albums, err := s.musicProvider.GetArtistAlbums(ctx, catalog.Language, id, page.Limit+1, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting albums of artist %q: %w", id, err)
+	}
+	return artistAlbumsPage(catalog, id, albums, page), nil
+}
+
+// albumTracksPage monta a página de faixas a partir da tracklist completa do álbum
+func albumTracksPage(catalog driving.CatalogParameters, id string, tracks []domain.Song, page driving.PageParameters) *driving.Page[domain.Song] {
+	songHrefs(catalog.Storefront, tracks)
+
+	// A tracklist é completa, então a paginação é feita aqui
+	href := domain.CatalogHref(catalog.Storefront, "albums", id) + "/tracks"
+	if page.Offset >= len(tracks) {
+		return &driving.Page[domain.Song]{Href: href, Data: []domain.Song{}}
+	}
+	end := page.Offset + page.Limit
+	if end > len(tracks) {
+		end = len(tracks)
+	}
+	return &driving.Page[domain.Song]{
+		Href: href,
+		Next: nextHref(href, page, end < len(tracks)),
+		Data: tracks[page.Offset:end],
+	}
+}
+
+// artistAlbumsPage monta a página de álbuns a partir de até page.Limit+1 álbuns
+// buscados a partir de page.Offset
+func artistAlbumsPage(catalog driving.CatalogParameters, id string, albums []domain.Album, page driving.PageParameters) *driving.Page[domain.Album] {
+	if albums == nil {
+		albums = []domain.Album{}
+	}
+	albumHrefs(catalog.Storefront, albums)
+	hasNext := len(albums) > page.Limit
+	if hasNext {
+		albums = albums[:page.Limit]
+	}
+	href := domain.CatalogHref(catalog.Storefront, "artists", id) + "/albums"
+	return &driving.Page[domain.Album]{
+		Href: href,
+		Next: nextHref(href, page, hasNext),
+		Data: albums,
+	}
+}
+
+// nextHref retorna o link da próxima página de um relacionamento, ou vazio se não houver
+func nextHref(href string, page driving.PageParameters, hasNext bool) string {
+	if !hasNext {
+		return ""
+	}
+	next := fmt.Sprintf("%s?offset=%d", href, page.Offset+page.Limit)
+	if page.Limit != driving.DefaultPageLimit {
+		next += fmt.Sprintf("&limit=%d", page.Limit)
+	}
+	return next
+}
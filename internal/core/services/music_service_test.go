@@ -0,0 +1,508 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockProvider é um mock do driven.MusicProvider para testes
+type mockProvider struct {
+	songs   []domain.Song
+	albums  []domain.Album
+	artists []domain.Artist
+	err     error
+}
+
+func (m *mockProvider) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	return m.songs, m.err
+}
+
+func (m *mockProvider) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
+	return m.albums, m.err
+}
+
+func (m *mockProvider) SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error) {
+	return m.artists, m.err
+}
+
+func (m *mockProvider) GetSong(ctx context.Context, language, id string) (*domain.Song, error) {
+	for _, song := range m.songs {
+		if song.ID == id {
+			return &song, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockProvider) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	for _, album := range m.albums {
+		if album.ID == id {
+			return &album, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockProvider) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	for _, artist := range m.artists {
+		if artist.ID == id {
+			return &artist, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockProvider) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	if _, err := m.GetAlbum(ctx, language, id); err != nil {
+		return nil, err
+	}
+	return m.songs, nil
+}
+
+func (m *mockProvider) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	if _, err := m.GetArtist(ctx, language, id); err != nil {
+		return nil, err
+	}
+	if offset >= len(m.albums) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(m.albums) {
+		end = len(m.albums)
+	}
+	return m.albums[offset:end], nil
+}
+
+func (m *mockProvider) GetAlbumWithTracks(ctx context.Context, language, id string) (*driven.AlbumWithTracks, error) {
+	album, err := m.GetAlbum(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	return &driven.AlbumWithTracks{Album: *album, Tracks: m.songs}, nil
+}
+
+func (m *mockProvider) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	artist, err := m.GetArtist(ctx, language, id)
+	if err != nil {
+		return nil, err
+	}
+	return &driven.ArtistWithAlbums{Artist: *artist, Albums: pageOf(m.albums, limit, 0)}, nil
+}
+
+func (m *mockProvider) GetTopSongs(ctx context.Context, language, storefront, genre string, limit, offset int) ([]domain.Song, error) {
+	return pageOf(m.songs, limit, offset), m.err
+}
+
+func (m *mockProvider) GetTopAlbums(ctx context.Context, language, genre string, limit, offset int) ([]domain.Album, error) {
+	return pageOf(m.albums, limit, offset), m.err
+}
+
+// pageOf aplica offset e limit a uma lista do mock
+func pageOf[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+// newCatalog cria um mock com um artista, n álbuns e n faixas
+func newCatalog(n int) *mockProvider {
+	provider := &mockProvider{
+		artists: []domain.Artist{{ID: "artist", Type: "artists"}},
+	}
+	for i := 0; i < n; i++ {
+		provider.songs = append(provider.songs, domain.Song{ID: fmt.Sprintf("song-%d", i), Type: "songs"})
+		provider.albums = append(provider.albums, domain.Album{
+			ID:   fmt.Sprintf("album-%d", i),
+			Type: "albums",
+			Href: fmt.Sprintf("/v1/catalog/us/albums/album-%d", i),
+		})
+	}
+	return provider
+}
+
+var us = driving.CatalogParameters{Storefront: "us"}
+
+func TestMusicService_GetAlbumTracks(t *testing.T) {
+	service := NewMusicService(newCatalog(30))
+
+	page, err := service.GetAlbumTracks(context.Background(), us, "album-0", driving.PageParameters{Limit: 25})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 25)
+	assert.Equal(t, "/v1/catalog/us/albums/album-0/tracks", page.Href)
+	assert.Equal(t, "/v1/catalog/us/albums/album-0/tracks?offset=25", page.Next)
+
+	page, err = service.GetAlbumTracks(context.Background(), us, "album-0", driving.PageParameters{Limit: 10, Offset: 25})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 5)
+	assert.Equal(t, "song-25", page.Data[0].ID)
+	assert.Empty(t, page.Next)
+
+	page, err = service.GetAlbumTracks(context.Background(), us, "album-0", driving.PageParameters{Limit: 10, Offset: 40})
+	assert.NoError(t, err)
+	assert.NotNil(t, page.Data)
+	assert.Empty(t, page.Data)
+
+	_, err = service.GetAlbumTracks(context.Background(), us, "unknown", driving.PageParameters{Limit: 10})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestMusicService_GetArtistAlbums(t *testing.T) {
+	service := NewMusicService(newCatalog(12))
+
+	page, err := service.GetArtistAlbums(context.Background(), us, "artist", driving.PageParameters{Limit: 5, Offset: 5})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 5)
+	assert.Equal(t, "album-5", page.Data[0].ID)
+	assert.Equal(t, "/v1/catalog/us/artists/artist/albums?offset=10&limit=5", page.Next)
+
+	page, err = service.GetArtistAlbums(context.Background(), us, "artist", driving.PageParameters{Limit: 5, Offset: 10})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.Empty(t, page.Next)
+}
+
+func TestMusicService_InlineRelationships(t *testing.T) {
+	service := NewMusicService(newCatalog(30))
+
+	album, err := service.GetAlbum(context.Background(), us, "album-0")
+	assert.NoError(t, err)
+	if assert.NotNil(t, album.Relationships) {
+		assert.Len(t, album.Relationships.Tracks.Data, driving.DefaultPageLimit)
+		assert.NotEmpty(t, album.Relationships.Tracks.Next)
+	}
+
+	artist, err := service.GetArtist(context.Background(), us, "artist")
+	assert.NoError(t, err)
+	if assert.NotNil(t, artist.Relationships) {
+		assert.Len(t, artist.Relationships.Albums.Data, driving.DefaultPageLimit)
+		assert.Equal(t, domain.ResourceRef{
+			ID:   "album-0",
+			Type: "albums",
+			Href: "/v1/catalog/us/albums/album-0",
+		}, artist.Relationships.Albums.Data[0])
+	}
+}
+
+func TestMusicService_InlineRelationshipsUseOneLookup(t *testing.T) {
+	service := NewMusicService(&combinedLookupsOnly{t: t, mockProvider: newCatalog(3)})
+
+	album, err := service.GetAlbum(context.Background(), us, "album-0")
+	assert.NoError(t, err)
+	if assert.NotNil(t, album.Relationships) {
+		assert.Len(t, album.Relationships.Tracks.Data, 3)
+		assert.Equal(t, "/v1/catalog/us/songs/song-0", album.Relationships.Tracks.Data[0].Href)
+	}
+
+	artist, err := service.GetArtist(context.Background(), us, "artist")
+	assert.NoError(t, err)
+	if assert.NotNil(t, artist.Relationships) {
+		assert.Len(t, artist.Relationships.Albums.Data, 3)
+		assert.Empty(t, artist.Relationships.Albums.Next)
+	}
+}
+
+// combinedLookupsOnly falha o teste se o álbum ou o artista forem buscados
+// separadamente dos seus relacionamentos
+type combinedLookupsOnly struct {
+	*mockProvider
+	t *testing.T
+}
+
+func (c *combinedLookupsOnly) GetAlbum(ctx context.Context, language, id string) (*domain.Album, error) {
+	c.t.Error("unexpected GetAlbum")
+	return c.mockProvider.GetAlbum(ctx, language, id)
+}
+
+func (c *combinedLookupsOnly) GetAlbumTracks(ctx context.Context, language, id string) ([]domain.Song, error) {
+	c.t.Error("unexpected GetAlbumTracks")
+	return c.mockProvider.GetAlbumTracks(ctx, language, id)
+}
+
+func (c *combinedLookupsOnly) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	c.t.Error("unexpected GetArtist")
+	return c.mockProvider.GetArtist(ctx, language, id)
+}
+
+func (c *combinedLookupsOnly) GetArtistAlbums(ctx context.Context, language, id string, limit, offset int) ([]domain.Album, error) {
+	c.t.Error("unexpected GetArtistAlbums")
+	return c.mockProvider.GetArtistAlbums(ctx, language, id, limit, offset)
+}
+
+func TestMusicService_GetSongs(t *testing.T) {
+	service := NewMusicService(newCatalog(20))
+
+	songs, err := service.GetSongs(context.Background(), us, []string{"song-7", "unknown", "song-2", "song-15"})
+	assert.NoError(t, err)
+	ids := make([]string, 0, len(songs))
+	for _, song := range songs {
+		ids = append(ids, song.ID)
+	}
+	assert.Equal(t, []string{"song-7", "song-2", "song-15"}, ids)
+
+	songs, err = service.GetSongs(context.Background(), us, []string{"unknown"})
+	assert.NoError(t, err)
+	assert.NotNil(t, songs)
+	assert.Empty(t, songs)
+}
+
+func TestMusicService_GetArtistsProviderError(t *testing.T) {
+	provider := newCatalog(1)
+	provider.err = assert.AnError
+	service := NewMusicService(&failingLookups{provider})
+
+	_, err := service.GetArtists(context.Background(), us, []string{"artist", "unknown"})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMusicService_GetSongsCanceled(t *testing.T) {
+	service := NewMusicService(newCatalog(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := service.GetSongs(ctx, us, []string{"song-0", "song-1"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// failingLookups faz as buscas por ID retornarem o erro configurado no mock
+type failingLookups struct {
+	*mockProvider
+}
+
+func (f *failingLookups) GetArtist(ctx context.Context, language, id string) (*domain.Artist, error) {
+	return nil, f.err
+}
+
+func (f *failingLookups) GetArtistWithAlbums(ctx context.Context, language, id string, limit int) (*driven.ArtistWithAlbums, error) {
+	return nil, f.err
+}
+
+func TestMusicService_CodesRoundTrip(t *testing.T) {
+	service := NewMusicService(newCatalog(3))
+
+	results, err := service.Search(context.Background(), driving.SearchParameters{
+		CatalogParameters: us,
+		Term:              "test",
+		Limit:             5,
+		Types:             []driving.SearchResultType{driving.SongsType, driving.AlbumsType},
+	})
+	assert.NoError(t, err)
+	isrc := results.Songs[1].Attributes.ISRC
+	upc := results.Albums[2].Attributes.UPC
+	assert.True(t, domain.ValidISRC(isrc))
+	assert.True(t, domain.ValidUPC(upc))
+
+	songs, err := service.GetSongsByISRC(context.Background(), us, []string{isrc})
+	assert.NoError(t, err)
+	if assert.Len(t, songs, 1) {
+		assert.Equal(t, "song-1", songs[0].ID)
+		assert.Equal(t, isrc, songs[0].Attributes.ISRC)
+	}
+
+	albums, err := service.GetAlbumsByUPC(context.Background(), us, []string{upc, domain.UPCFor("never-emitted")})
+	assert.NoError(t, err)
+	if assert.Len(t, albums, 1) {
+		assert.Equal(t, "album-2", albums[0].ID)
+		assert.Equal(t, upc, albums[0].Attributes.UPC)
+	}
+}
+
+func TestMusicService_CodesAreBounded(t *testing.T) {
+	codes := NewCodedProvider(newCatalog(3), 2)
+	service := NewMusicService(codes)
+	ctx := context.Background()
+
+	emit := func(id string) string {
+		song, err := service.GetSong(ctx, us, id)
+		assert.NoError(t, err)
+		return song.Attributes.ISRC
+	}
+	first, second := emit("song-0"), emit("song-1")
+
+	// Buscar um código o torna o mais recente, então o terceiro descarta o segundo
+	songs, err := service.GetSongsByISRC(ctx, us, []string{first})
+	assert.NoError(t, err)
+	assert.Len(t, songs, 1)
+	third := emit("song-2")
+
+	songs, err = service.GetSongsByISRC(ctx, us, []string{first, second, third})
+	assert.NoError(t, err)
+	var ids []string
+	for _, song := range songs {
+		ids = append(ids, song.ID)
+	}
+	assert.Equal(t, []string{"song-0", "song-2"}, ids)
+	assert.Equal(t, 2, codes.songsByISRC.len())
+}
+
+func TestMusicService_SearchGenre(t *testing.T) {
+	provider := &mockProvider{
+		songs: []domain.Song{
+			{ID: "rock", Attributes: domain.SongAttributes{GenreNames: []string{"Rock", "Music"}}},
+			{ID: "hard-rock", Attributes: domain.SongAttributes{GenreNames: []string{"Hard Rock", "Music"}}},
+			{ID: "pop", Attributes: domain.SongAttributes{GenreNames: []string{"Pop", "Music"}}},
+		},
+		artists: []domain.Artist{{ID: "untagged", Attributes: domain.ArtistAttributes{GenreNames: []string{"Music"}}}},
+	}
+	service := NewMusicService(provider)
+
+	// O gênero inclui os seus subgêneros
+	results, err := service.Search(context.Background(), driving.SearchParameters{
+		CatalogParameters: us,
+		Term:              "test",
+		Genre:             "21",
+		Limit:             5,
+		Types:             []driving.SearchResultType{driving.SongsType, driving.ArtistsType},
+	})
+	assert.NoError(t, err)
+	var ids []string
+	for _, song := range results.Songs {
+		ids = append(ids, song.ID)
+	}
+	assert.Equal(t, []string{"rock", "hard-rock"}, ids)
+	assert.Empty(t, results.Artists)
+
+	// Todos os resultados pertencem ao gênero raiz
+	results, err = service.Search(context.Background(), driving.SearchParameters{
+		CatalogParameters: us,
+		Term:              "test",
+		Genre:             domain.MusicGenreID,
+		Limit:             5,
+		Types:             []driving.SearchResultType{driving.SongsType, driving.ArtistsType},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results.Songs, 3)
+	assert.Len(t, results.Artists, 1)
+}
+
+func TestMusicService_SearchGenreDisabled(t *testing.T) {
+	service := NewMusicService(newCatalog(3), WithGenreFilter(false))
+	params := driving.SearchParameters{
+		CatalogParameters: us,
+		Term:              "test",
+		Genre:             "21",
+		Limit:             5,
+		Types:             []driving.SearchResultType{driving.SongsType},
+	}
+
+	_, err := service.Search(context.Background(), params)
+	assert.Equal(t, domain.KindInvalidParameter, domain.KindOf(err))
+	assert.Equal(t, "genre", domain.ParameterOf(err))
+
+	// O gênero raiz não filtra nada
+	params.Genre = domain.MusicGenreID
+	results, err := service.Search(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Len(t, results.Songs, 3)
+}
+
+func TestMusicService_StorefrontHrefs(t *testing.T) {
+	service := NewMusicService(newCatalog(30))
+	br := driving.CatalogParameters{Storefront: "br"}
+
+	album, err := service.GetAlbum(context.Background(), br, "album-0")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/catalog/br/albums/album-0", album.Href)
+	assert.Equal(t, "/v1/catalog/br/albums/album-0/tracks", album.Relationships.Tracks.Href)
+	assert.Equal(t, "/v1/catalog/br/songs/song-0", album.Relationships.Tracks.Data[0].Href)
+
+	artist, err := service.GetArtist(context.Background(), br, "artist")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/catalog/br/artists/artist/albums?offset=25", artist.Relationships.Albums.Next)
+	assert.Equal(t, "/v1/catalog/br/albums/album-0", artist.Relationships.Albums.Data[0].Href)
+}
+
+// searchProvider permite configurar o erro e a demora da busca de cada tipo
+type searchProvider struct {
+	*mockProvider
+	albumsErr error
+	delay     time.Duration
+	// blockArtists faz a busca de artistas esperar o fim do contexto
+	blockArtists bool
+}
+
+func (p *searchProvider) SearchSongs(ctx context.Context, language, term string, limit, offset int) ([]domain.Song, error) {
+	time.Sleep(p.delay)
+	return p.mockProvider.SearchSongs(ctx, language, term, limit, offset)
+}
+
+func (p *searchProvider) SearchAlbums(ctx context.Context, language, term string, limit, offset int) ([]domain.Album, error) {
+	time.Sleep(p.delay)
+	if p.albumsErr != nil {
+		return nil, p.albumsErr
+	}
+	return p.mockProvider.SearchAlbums(ctx, language, term, limit, offset)
+}
+
+func (p *searchProvider) SearchArtists(ctx context.Context, language, term string, limit, offset int) ([]domain.Artist, error) {
+	time.Sleep(p.delay)
+	if p.blockArtists {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return p.mockProvider.SearchArtists(ctx, language, term, limit, offset)
+}
+
+var allTypes = []driving.SearchResultType{driving.ArtistsType, driving.SongsType, driving.AlbumsType}
+
+func TestMusicService_SearchConcurrent(t *testing.T) {
+	service := NewMusicService(&searchProvider{mockProvider: newCatalog(2), delay: 50 * time.Millisecond})
+
+	start := time.Now()
+	results, err := service.Search(context.Background(), driving.SearchParameters{CatalogParameters: us, Term: "test", Limit: 5, Types: allTypes})
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 140*time.Millisecond, "types are searched concurrently")
+	assert.Len(t, results.Songs, 2)
+	assert.Len(t, results.Albums, 2)
+	assert.Len(t, results.Artists, 1)
+	assert.Empty(t, results.Failures)
+
+	// Com paralelismo 1 as buscas voltam a ser sequenciais
+	service = NewMusicService(&searchProvider{mockProvider: newCatalog(2), delay: 50 * time.Millisecond}, WithMaxConcurrentSearches(1))
+	start = time.Now()
+	_, err = service.Search(context.Background(), driving.SearchParameters{CatalogParameters: us, Term: "test", Limit: 5, Types: allTypes})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestMusicService_SearchPartialResults(t *testing.T) {
+	provider := &searchProvider{mockProvider: newCatalog(2), albumsErr: domain.UpstreamError("Last.fm request failed", assert.AnError)}
+	service := NewMusicService(provider)
+
+	results, err := service.Search(context.Background(), driving.SearchParameters{CatalogParameters: us, Term: "test", Limit: 5, Types: allTypes})
+	assert.NoError(t, err)
+	assert.Len(t, results.Songs, 2)
+	assert.Len(t, results.Artists, 1)
+	assert.Nil(t, results.Albums)
+	if assert.Len(t, results.Failures, 1) {
+		assert.Equal(t, driving.AlbumsType, results.Failures[0].Type)
+		assert.Equal(t, domain.KindUpstream, domain.KindOf(results.Failures[0].Err))
+	}
+	assert.True(t, results.Failed(driving.AlbumsType))
+	assert.False(t, results.Failed(driving.SongsType))
+
+	// Se todos os tipos falham, a busca falha
+	_, err = service.Search(context.Background(), driving.SearchParameters{CatalogParameters: us, Term: "test", Limit: 5, Types: []driving.SearchResultType{driving.AlbumsType}})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMusicService_SearchTypeTimeout(t *testing.T) {
+	provider := &searchProvider{mockProvider: newCatalog(2), blockArtists: true}
+	service := NewMusicService(provider, WithSearchTimeout(20*time.Millisecond))
+
+	results, err := service.Search(context.Background(), driving.SearchParameters{CatalogParameters: us, Term: "test", Limit: 5, Types: allTypes})
+	assert.NoError(t, err)
+	assert.Len(t, results.Songs, 2)
+	if assert.Len(t, results.Failures, 1) {
+		assert.Equal(t, driving.ArtistsType, results.Failures[0].Type)
+		assert.Equal(t, domain.KindTimeout, domain.KindOf(results.Failures[0].Err))
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import "time"
+
+// Valores padrão da busca
+const (
+	// DefaultSearchTimeout é o prazo padrão da busca de cada tipo
+	DefaultSearchTimeout = 10 * time.Second
+	// DefaultMaxConcurrentSearches é o número padrão de tipos buscados simultaneamente
+	DefaultMaxConcurrentSearches = 3
+)
+
+// Option configura o MusicService
+type Option func(*options)
+
+type options struct {
+	searchTimeout         time.Duration
+	maxConcurrentSearches int
+	genreFilter           bool
+}
+
+// WithSearchTimeout define o prazo da busca de cada tipo (músicas, álbuns e artistas)
+func WithSearchTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		if timeout > 0 {
+			o.searchTimeout = timeout
+		}
+	}
+}
+
+// WithMaxConcurrentSearches define quantos tipos podem ser buscados ao mesmo tempo
+func WithMaxConcurrentSearches(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxConcurrentSearches = n
+		}
+	}
+}
+
+// WithGenreFilter define se a busca aceita o filtro por gênero (o padrão). Deve ser
+// desabilitada quando o provedor não atribui gêneros aos resultados das buscas, para
+// que o filtro seja rejeitado em vez de retornar páginas vazias.
+func WithGenreFilter(enabled bool) Option {
+	return func(o *options) {
+		o.genreFilter = enabled
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		searchTimeout:         DefaultSearchTimeout,
+		maxConcurrentSearches: DefaultMaxConcurrentSearches,
+		genreFilter:           true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
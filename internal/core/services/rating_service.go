@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driven"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+type RatingService struct {
+	musicProvider driven.MusicProvider
+	playlists     driven.PlaylistRepository
+	ratings       driven.RatingRepository
+	now           func() time.Time
+}
+
+func NewRatingService(musicProvider driven.MusicProvider, playlists driven.PlaylistRepository, ratings driven.RatingRepository) driving.RatingService {
+	return &RatingService{
+		musicProvider: codedProviderFor(musicProvider),
+		playlists:     playlists,
+		ratings:       ratings,
+		now:           time.Now,
+	}
+}
+
+func (s *RatingService) GetRating(ctx context.Context, user *domain.User, resourceType, id string) (*domain.Rating, error) {
+	if err := checkRatingType(resourceType); err != nil {
+		return nil, err
+	}
+	rating, err := s.rating(ctx, user, resourceType, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rating for %s %q: %w", resourceType, id, err)
+	}
+	return rating, nil
+}
+
+func (s *RatingService) GetRatings(ctx context.Context, user *domain.User, resourceType string, ids []string) ([]domain.Rating, error) {
+	if err := checkRatingType(resourceType); err != nil {
+		return nil, err
+	}
+	ratings := make([]domain.Rating, 0, len(ids))
+	for _, id := range ids {
+		rating, err := s.rating(ctx, user, resourceType, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting rating for %s %q: %w", resourceType, id, err)
+		}
+		ratings = append(ratings, *rating)
+	}
+	return ratings, nil
+}
+
+func (s *RatingService) SetRating(ctx context.Context, user *domain.User, resourceType, id string, value domain.RatingValue) (*domain.Rating, error) {
+	if err := checkRatingType(resourceType); err != nil {
+		return nil, err
+	}
+	if !value.Valid() {
+		return nil, domain.InvalidParameter("", "rating value must be 1 (love) or -1 (dislike)")
+	}
+
+	// Apenas recursos existentes podem ser avaliados
+	var err error
+	switch resourceType {
+	case driving.RatingTypeSongs:
+		_, err = s.musicProvider.GetSong(ctx, "", id)
+	case driving.RatingTypeAlbums:
+		_, err = s.musicProvider.GetAlbum(ctx, "", id)
+	case driving.RatingTypeLibraryPlaylists:
+		_, err = s.playlists.GetPlaylist(ctx, user.ID, id)
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.InvalidParameter("", fmt.Sprintf("%s %s not found", resourceType, id))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s %q: %w", resourceType, id, err)
+	}
+
+	rating := domain.Rating{ResourceType: resourceType, ResourceID: id, Value: value, UpdatedAt: s.now()}
+	if err := s.ratings.PutRating(ctx, user.ID, rating); err != nil {
+		return nil, fmt.Errorf("error saving rating for %s %q: %w", resourceType, id, err)
+	}
+	return &rating, nil
+}
+
+func (s *RatingService) DeleteRating(ctx context.Context, user *domain.User, resourceType, id string) error {
+	if err := checkRatingType(resourceType); err != nil {
+		return err
+	}
+	if err := s.ratings.DeleteRating(ctx, user.ID, resourceType, id); err != nil {
+		return fmt.Errorf("error deleting rating for %s %q: %w", resourceType, id, err)
+	}
+	return nil
+}
+
+// rating retorna a avaliação guardada do recurso. As avaliações de playlists que
+// não existem mais, como as guardadas em RATINGS_PATH antes de uma
+// reinicialização, são tratadas como inexistentes.
+func (s *RatingService) rating(ctx context.Context, user *domain.User, resourceType, id string) (*domain.Rating, error) {
+	rating, err := s.ratings.GetRating(ctx, user.ID, resourceType, id)
+	if err != nil || resourceType != driving.RatingTypeLibraryPlaylists {
+		return rating, err
+	}
+	_, err = s.playlists.GetPlaylist(ctx, user.ID, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.NotFound(fmt.Sprintf("rating for %s %s not found", resourceType, id))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+// checkRatingType verifica se o tipo de recurso pode ser avaliado
+func checkRatingType(resourceType string) error {
+	switch resourceType {
+	case driving.RatingTypeSongs, driving.RatingTypeAlbums, driving.RatingTypeLibraryPlaylists:
+		return nil
+	}
+	return domain.NotFound(fmt.Sprintf("ratings for %s are not supported", resourceType))
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"applemusic-api-simulator/internal/adapters/driven/memory"
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingService(t *testing.T) {
+	provider := &mockProvider{songs: []domain.Song{{ID: "song-1"}, {ID: "song-2"}}, albums: []domain.Album{{ID: "album-1"}}}
+	playlists := memory.NewPlaylistRepository()
+	ctx := context.Background()
+	require.NoError(t, playlists.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.1", Name: "Mine"}))
+	service := NewRatingService(provider, playlists, memory.NewRatingRepository())
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	rating, err := service.SetRating(ctx, user, driving.RatingTypeSongs, "song-1", domain.RatingLove)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingLove, rating.Value)
+	_, err = service.SetRating(ctx, user, driving.RatingTypeAlbums, "album-1", domain.RatingDislike)
+	require.NoError(t, err)
+	_, err = service.SetRating(ctx, user, driving.RatingTypeLibraryPlaylists, "p.1", domain.RatingLove)
+	require.NoError(t, err)
+
+	rating, err = service.GetRating(ctx, user, driving.RatingTypeAlbums, "album-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingDislike, rating.Value)
+
+	// Recursos não avaliados são omitidos na busca de várias avaliações
+	ratings, err := service.GetRatings(ctx, user, driving.RatingTypeSongs, []string{"song-2", "song-1"})
+	require.NoError(t, err)
+	if assert.Len(t, ratings, 1) {
+		assert.Equal(t, "song-1", ratings[0].ResourceID)
+	}
+
+	rating, err = service.GetRating(ctx, user, driving.RatingTypeLibraryPlaylists, "p.1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RatingLove, rating.Value)
+
+	require.NoError(t, service.DeleteRating(ctx, user, driving.RatingTypeSongs, "song-1"))
+	_, err = service.GetRating(ctx, user, driving.RatingTypeSongs, "song-1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.True(t, errors.Is(service.DeleteRating(ctx, user, driving.RatingTypeSongs, "song-1"), domain.ErrNotFound))
+}
+
+func TestRatingService_Validation(t *testing.T) {
+	provider := &mockProvider{songs: []domain.Song{{ID: "song-1"}}}
+	playlists := memory.NewPlaylistRepository()
+	ctx := context.Background()
+	require.NoError(t, playlists.CreatePlaylist(ctx, "bob", domain.Playlist{ID: "p.1", Name: "Bob's"}))
+	service := NewRatingService(provider, playlists, memory.NewRatingRepository())
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	tests := []struct {
+		name         string
+		resourceType string
+		id           string
+		value        domain.RatingValue
+		kind         domain.ErrorKind
+	}{
+		{"Invalid value", driving.RatingTypeSongs, "song-1", 2, domain.KindInvalidParameter},
+		{"Zero value", driving.RatingTypeSongs, "song-1", 0, domain.KindInvalidParameter},
+		{"Unknown song", driving.RatingTypeSongs, "unknown", domain.RatingLove, domain.KindInvalidParameter},
+		{"Unknown album", driving.RatingTypeAlbums, "unknown", domain.RatingLove, domain.KindInvalidParameter},
+		{"Playlist of another user", driving.RatingTypeLibraryPlaylists, "p.1", domain.RatingLove, domain.KindInvalidParameter},
+		{"Unsupported type", "stations", "ra.1", domain.RatingLove, domain.KindNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetRating(ctx, user, tt.resourceType, tt.id, tt.value)
+			assert.Equal(t, tt.kind, domain.KindOf(err))
+		})
+	}
+
+	_, err := service.GetRating(ctx, user, driving.RatingTypeSongs, "song-1")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "rejected ratings are not saved")
+}
+
+func TestRatingService_DeletedPlaylist(t *testing.T) {
+	provider := &mockProvider{}
+	playlists := memory.NewPlaylistRepository()
+	ratings := memory.NewRatingRepository()
+	ctx := context.Background()
+	require.NoError(t, playlists.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.1", Name: "Mine"}))
+	require.NoError(t, playlists.CreatePlaylist(ctx, "alice", domain.Playlist{ID: "p.2", Name: "Also mine"}))
+	// A avaliação de p.gone sobreviveu à playlist, como após reiniciar com RATINGS_PATH
+	require.NoError(t, ratings.PutRating(ctx, "alice", domain.Rating{ResourceType: driving.RatingTypeLibraryPlaylists, ResourceID: "p.gone", Value: domain.RatingLove}))
+	service := NewRatingService(provider, playlists, ratings)
+	user := &domain.User{ID: "alice", Storefront: "us"}
+
+	_, err := service.SetRating(ctx, user, driving.RatingTypeLibraryPlaylists, "p.1", domain.RatingLove)
+	require.NoError(t, err)
+
+	_, err = service.GetRating(ctx, user, driving.RatingTypeLibraryPlaylists, "p.gone")
+	assert.Equal(t, domain.KindNotFound, domain.KindOf(err))
+
+	list, err := service.GetRatings(ctx, user, driving.RatingTypeLibraryPlaylists, []string{"p.gone", "p.1", "p.2"})
+	require.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "p.1", list[0].ResourceID)
+	}
+}
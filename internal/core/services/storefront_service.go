@@ -0,0 +1,26 @@
+package services
+
+import (
+	"fmt"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+type StorefrontService struct{}
+
+func NewStorefrontService() driving.StorefrontService {
+	return &StorefrontService{}
+}
+
+func (s *StorefrontService) ListStorefronts() []domain.Storefront {
+	return domain.Storefronts()
+}
+
+func (s *StorefrontService) GetStorefront(id string) (*domain.Storefront, error) {
+	storefront, ok := domain.FindStorefront(id)
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("storefront %s not found", id))
+	}
+	return storefront, nil
+}
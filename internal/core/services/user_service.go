@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+)
+
+// Valores padrão dos Music-User-Tokens emitidos
+const (
+	// DefaultUserTokenTTL é a validade padrão de um token, como os da Apple
+	DefaultUserTokenTTL = 180 * 24 * time.Hour
+	// DefaultUserStorefront é o storefront dos usuários criados sem um storefront
+	DefaultUserStorefront = "us"
+)
+
+// tokenSweepInterval é o intervalo mínimo entre as limpezas dos tokens expirados
+const tokenSweepInterval = time.Minute
+
+// UserService mantém em memória os usuários simulados e os seus tokens
+type UserService struct {
+	now func() time.Time
+
+	mu        sync.RWMutex
+	users     map[string]*domain.User
+	tokens    map[string]*domain.UserToken
+	lastSweep time.Time
+}
+
+func NewUserService() driving.UserService {
+	return &UserService{
+		now:    time.Now,
+		users:  make(map[string]*domain.User),
+		tokens: make(map[string]*domain.UserToken),
+	}
+}
+
+func (s *UserService) IssueToken(ctx context.Context, params driving.IssueUserTokenParameters) (*domain.UserToken, error) {
+	var storefront string
+	if params.Storefront != "" {
+		sf, ok := domain.FindStorefront(params.Storefront)
+		if !ok {
+			return nil, domain.InvalidParameter("storefront", fmt.Sprintf("storefront %s is not supported", params.Storefront))
+		}
+		storefront = sf.ID
+	}
+	if params.TTL < 0 {
+		return nil, domain.InvalidParameter("expiresIn", "token lifetime must be positive")
+	}
+	ttl := params.TTL
+	if ttl == 0 {
+		ttl = DefaultUserTokenTTL
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID := params.UserID
+	if userID == "" {
+		userID = "user-" + randomHex(8)
+	}
+	user, ok := s.users[userID]
+	if !ok {
+		user = &domain.User{ID: userID, Storefront: DefaultUserStorefront}
+		s.users[userID] = user
+	}
+	if storefront != "" {
+		user.Storefront = storefront
+	}
+
+	now := s.now()
+	s.sweep(now)
+	token := &domain.UserToken{
+		Token:     randomToken(),
+		User:      *user,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+	s.tokens[token.Token] = token
+	return token, nil
+}
+
+func (s *UserService) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userToken, ok := s.tokens[token]
+	if !ok {
+		return nil, domain.Forbidden("Music-User-Token is invalid or has been revoked")
+	}
+	if userToken.Expired(s.now()) {
+		return nil, domain.Forbidden("Music-User-Token has expired")
+	}
+	// O usuário pode ter mudado de storefront desde a emissão do token
+	user := *s.users[userToken.User.ID]
+	return &user, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, domain.NotFound(fmt.Sprintf("user %s not found", id))
+	}
+	u := *user
+	return &u, nil
+}
+
+func (s *UserService) RevokeToken(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[token]; !ok {
+		return domain.NotFound("user token not found")
+	}
+	delete(s.tokens, token)
+	return nil
+}
+
+// sweep remove os tokens expirados, que não autenticam mais nenhuma requisição.
+// Deve ser chamado com s.mu travado.
+func (s *UserService) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < tokenSweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, token := range s.tokens {
+		if token.Expired(now) {
+			delete(s.tokens, key)
+		}
+	}
+}
+
+// randomToken gera um token opaco, como os Music-User-Tokens da Apple
+func randomToken() string {
+	b := make([]byte, 48)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applemusic-api-simulator/internal/core/domain"
+	"applemusic-api-simulator/internal/core/ports/driving"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_IssueAndAuthenticate(t *testing.T) {
+	service := NewUserService()
+	ctx := context.Background()
+
+	token, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.Token)
+	assert.NotEmpty(t, token.User.ID)
+	assert.Equal(t, DefaultUserStorefront, token.User.Storefront)
+	assert.Equal(t, DefaultUserTokenTTL, token.ExpiresAt.Sub(token.IssuedAt))
+
+	user, err := service.Authenticate(ctx, token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, token.User, *user)
+
+	// Um novo token para o mesmo usuário pode mudar o storefront dele
+	second, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{UserID: user.ID, Storefront: "br"})
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Token, second.Token)
+	user, err = service.Authenticate(ctx, token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "br", user.Storefront, "every token of the user sees the new storefront")
+
+	// O storefront é guardado com o ID canônico
+	third, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{UserID: user.ID, Storefront: "GB"})
+	require.NoError(t, err)
+	assert.Equal(t, "gb", third.User.Storefront)
+
+	_, err = service.Authenticate(ctx, "unknown")
+	assert.Equal(t, domain.KindForbidden, domain.KindOf(err))
+}
+
+func TestUserService_Expiry(t *testing.T) {
+	service := NewUserService()
+	now := time.Now()
+	service.(*UserService).now = func() time.Time { return now }
+	ctx := context.Background()
+
+	token, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{UserID: "test-user", TTL: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, "test-user", token.User.ID)
+
+	now = now.Add(time.Hour - time.Second)
+	_, err = service.Authenticate(ctx, token.Token)
+	assert.NoError(t, err)
+
+	now = now.Add(time.Second)
+	_, err = service.Authenticate(ctx, token.Token)
+	assert.Equal(t, domain.KindForbidden, domain.KindOf(err))
+
+	// Os tokens expirados são descartados na emissão seguinte
+	now = now.Add(tokenSweepInterval)
+	second, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{UserID: "test-user"})
+	require.NoError(t, err)
+	assert.Len(t, service.(*UserService).tokens, 1)
+	_, err = service.Authenticate(ctx, second.Token)
+	assert.NoError(t, err)
+}
+
+func TestUserService_Revoke(t *testing.T) {
+	service := NewUserService()
+	ctx := context.Background()
+
+	token, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{})
+	require.NoError(t, err)
+	require.NoError(t, service.RevokeToken(ctx, token.Token))
+
+	_, err = service.Authenticate(ctx, token.Token)
+	assert.Equal(t, domain.KindForbidden, domain.KindOf(err))
+	assert.ErrorIs(t, service.RevokeToken(ctx, token.Token), domain.ErrNotFound)
+}
+
+func TestUserService_InvalidParameters(t *testing.T) {
+	service := NewUserService()
+	ctx := context.Background()
+
+	_, err := service.IssueToken(ctx, driving.IssueUserTokenParameters{Storefront: "zz"})
+	assert.Equal(t, "storefront", domain.ParameterOf(err))
+	_, err = service.IssueToken(ctx, driving.IssueUserTokenParameters{TTL: -time.Hour})
+	assert.Equal(t, "expiresIn", domain.ParameterOf(err))
+}